@@ -2,15 +2,37 @@ package goodreads
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // DefaultAPIRoot specifies a root for the client, which we point at goodreads.com.
 // It's their API, after all.
 const defaultAPIRoot = "https://www.goodreads.com"
 
+// ErrNotFound is returned when Goodreads responds that the requested
+// resource does not exist.
+var ErrNotFound = errors.New("not found")
+
+// StatusError is returned when Goodreads responds with an unexpected status
+// code, other than 404 which is returned as ErrNotFound.
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected response code: %d", e.Code)
+}
+
 // The default client, which we configure to work with the Goodreads public API.
 var defaultAPIClient APIClient = &httpClient{
 	Client:  http.DefaultClient,
@@ -21,37 +43,257 @@ var defaultAPIClient APIClient = &httpClient{
 // against a Goodreads API function, with parameters,
 // and decode the response to a local struct.
 type APIClient interface {
-	Get(string, func([]byte, interface{}) error, url.Values, interface{}) error
+	Get(context.Context, string, func([]byte, interface{}) error, url.Values, interface{}) error
+}
+
+// Logger records the requests made by a Client.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+type stdoutLogger struct{}
+
+func (stdoutLogger) Printf(format string, v ...interface{}) {
+	fmt.Printf(format+"\n", v...)
 }
 
 type httpClient struct {
 	Client  *http.Client
 	APIRoot string
 	Verbose bool
+	Curl    bool
+	Logger  Logger
+
+	// RetryDecider, if set, is asked whether to resend a failed request.
+	// Requests that are not idempotent are only retried with RetryWrites.
+	RetryDecider func(res *http.Response, err error, attempt int) bool
+	RetryWrites  bool
+
+	// Sign, if set, authenticates each API call before it is sent.
+	Sign func(*http.Request) error
+}
+
+// rawGetter is implemented by APIClients that can return the undecoded body
+// of a response, along with its Content-Type.
+type rawGetter interface {
+	GetRaw(context.Context, string, url.Values) ([]byte, string, error)
+}
+
+func (h *httpClient) Get(ctx context.Context, endpoint string, decoder func([]byte, interface{}) error, q url.Values, v interface{}) error {
+	res, body, err := h.fetch(ctx, endpoint, q)
+	if err != nil {
+		return err
+	}
+	return responseDecoder(res, decoder)(body, v)
 }
 
-func (h *httpClient) Get(endpoint string, decoder func([]byte, interface{}) error, q url.Values, v interface{}) error {
+// GetRaw is like Get, but returns the body of the response as is, along
+// with its Content-Type.
+func (h *httpClient) GetRaw(ctx context.Context, endpoint string, q url.Values) ([]byte, string, error) {
+	res, body, err := h.fetch(ctx, endpoint, q)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, res.Header.Get("Content-Type"), nil
+}
+
+func (h *httpClient) fetch(ctx context.Context, endpoint string, q url.Values) (*http.Response, []byte, error) {
 	url := fmt.Sprintf("%s/%s?%s", h.APIRoot, endpoint, q.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if strings.HasSuffix(endpoint, ".json") {
+		req.Header.Set("Accept", "application/json")
+	} else {
+		req.Header.Set("Accept", "application/xml")
+	}
+	if h.Sign != nil {
+		if err := h.Sign(req); err != nil {
+			return nil, nil, err
+		}
+	}
+	return h.do(req)
+}
+
+// do sends a request, logging it as configured, and returns the response
+// along with its fully read body. Responses outside of the 2xx range are
+// returned as errors, after any retries the RetryDecider asks for.
+func (h *httpClient) do(req *http.Request) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		res, body, err := h.send(req)
+		if err == nil {
+			return res, body, nil
+		}
+		if !h.retry(req, res, err, attempt) {
+			return nil, nil, err
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+}
+
+// retry reports whether a failed request should be sent again, as decided by
+// the RetryDecider.
+func (h *httpClient) retry(req *http.Request, res *http.Response, err error, attempt int) bool {
+	if h.RetryDecider == nil || req.Context().Err() != nil {
+		return false
+	}
+	if !h.RetryWrites && !isIdempotent(req.Method) {
+		return false
+	}
+	return h.RetryDecider(res, err, attempt)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// send makes a single attempt at a request. On a response outside of the
+// 2xx range, the response is returned along with the error.
+func (h *httpClient) send(req *http.Request) (*http.Response, []byte, error) {
 	if h.Verbose {
-		fmt.Printf("GET %s\n", url)
+		h.logger().Printf("%s %s", req.Method, req.URL)
+	}
+	if h.Curl {
+		h.logger().Printf("%s", curlCommand(req))
 	}
 
-	res, err := h.Client.Get(url)
+	res, err := h.Client.Do(req)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	defer res.Body.Close()
 
-	buf := new(bytes.Buffer)
+	buf := bodyBuffers.Get().(*bytes.Buffer)
+	defer putBodyBuffer(buf)
+	buf.Reset()
 	_, err = buf.ReadFrom(res.Body)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
+	if res.StatusCode == http.StatusNotFound {
+		return res, nil, ErrNotFound
+	}
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		return fmt.Errorf("unexpected response code: %d", res.StatusCode)
+		return res, nil, &StatusError{Code: res.StatusCode}
+	}
+
+	// The buffer goes back to the pool, so the body is copied out at its
+	// final size.
+	return res, append([]byte(nil), buf.Bytes()...), nil
+}
+
+// maxPooledBodyBuffer is the capacity above which a body buffer is left for
+// the garbage collector rather than kept for reuse.
+const maxPooledBodyBuffer = 1 << 20
+
+// bodyBuffers holds the buffers responses are read into, so that each
+// request doesn't grow a new buffer from scratch.
+var bodyBuffers = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func putBodyBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBodyBuffer {
+		bodyBuffers.Put(buf)
+	}
+}
+
+// responseDecoder picks the decoder matching the Content-Type of a response,
+// so that a server returning a different format than requested is still
+// understood. The requested decoder is used when the type is not JSON or XML.
+func responseDecoder(res *http.Response, requested func([]byte, interface{}) error) func([]byte, interface{}) error {
+	return contentTypeDecoder(res.Header.Get("Content-Type"), requested)
+}
+
+// contentTypeDecoder is responseDecoder for a Content-Type header value.
+func contentTypeDecoder(contentType string, requested func([]byte, interface{}) error) func([]byte, interface{}) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return requested
+	}
+
+	switch {
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return json.Unmarshal
+	case mediaType == "application/xml", mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		return xml.Unmarshal
+	}
+	return requested
+}
+
+func (h *httpClient) logger() Logger {
+	if h.Logger == nil {
+		return stdoutLogger{}
+	}
+	return h.Logger
+}
+
+// curlCommand renders a request as a curl command line, replacing the API key
+// and any credentials in the Authorization header with placeholders.
+func curlCommand(req *http.Request) string {
+	u := *req.URL
+	q := u.Query()
+	if q.Get("key") != "" {
+		q.Set("key", "YOUR_API_KEY")
+		u.RawQuery = q.Encode()
 	}
 
-	return decoder(buf.Bytes(), v)
+	var b strings.Builder
+	b.WriteString("curl")
+	if req.Method != http.MethodGet {
+		b.WriteString(" -X " + req.Method)
+	}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if name == "Authorization" {
+				value = redactAuthorization(value)
+			}
+			b.WriteString(" -H " + shellQuote(name+": "+value))
+		}
+	}
+
+	b.WriteString(" " + shellQuote(u.String()))
+	return b.String()
+}
+
+// redactAuthorization hides the credentials of an Authorization header while
+// keeping its scheme and parameter names, for example
+// `OAuth oauth_consumer_key="REDACTED", oauth_signature="REDACTED"`.
+func redactAuthorization(value string) string {
+	parts := strings.SplitN(value, " ", 2)
+	if len(parts) < 2 {
+		return "REDACTED"
+	}
+
+	params := strings.Split(parts[1], ",")
+	for i, param := range params {
+		param = strings.TrimSpace(param)
+		if eq := strings.Index(param, "="); eq > 0 {
+			params[i] = param[:eq] + `="REDACTED"`
+		} else {
+			params[i] = "REDACTED"
+		}
+	}
+	return parts[0] + " " + strings.Join(params, ", ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
 }
@@ -1,14 +1,17 @@
 package goodreads
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/KyleBanks/goodreads/responses"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,9 +40,104 @@ func TestHttpClient_Get(t *testing.T) {
 				ID string `xml:"id" json:"id"`
 			}
 			h := httpClient{Client: http.DefaultClient, APIRoot: s.URL, Verbose: true}
-			err := h.Get("foo/bar", tc.Decoder, v, &res)
+			err := h.Get(context.Background(), "foo/bar", tc.Decoder, v, &res)
 			assert.Nil(t, err)
 			assert.Equal(t, "SampleID", res.ID)
 		})
 	}
 }
+
+func TestHttpClient_GetNotFound(t *testing.T) {
+	s := httptest.NewServer(http.NotFoundHandler())
+	defer s.Close()
+
+	h := httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+	err := h.Get(context.Background(), "foo/bar", xml.Unmarshal, url.Values{}, nil)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestCurlCommand(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://www.goodreads.com/author/show/1?key=secret&page=2", nil)
+	req.Header.Set("Authorization", `OAuth oauth_consumer_key="abc", oauth_token="def", oauth_signature="ghi"`)
+	req.Header.Set("Accept", "application/xml")
+
+	assert.Equal(t,
+		`curl -H 'Accept: application/xml' -H 'Authorization: OAuth oauth_consumer_key="REDACTED", oauth_token="REDACTED", oauth_signature="REDACTED"' 'https://www.goodreads.com/author/show/1?key=YOUR_API_KEY&page=2'`,
+		curlCommand(req))
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
+
+func TestHttpClient_GetNegotiatesFormat(t *testing.T) {
+	xmlBody := `<?xml version="1.0" encoding="UTF-8"?><response><id>SampleID</id></response>`
+	jsonBody := `{ "id": "SampleID" }`
+	testCases := []struct {
+		name         string
+		endpoint     string
+		decoder      func([]byte, interface{}) error
+		expectAccept string
+		contentType  string
+		body         string
+	}{
+		{"json as requested", "foo.json", json.Unmarshal, "application/json", "application/json; charset=utf-8", jsonBody},
+		{"xml as requested", "foo.xml", xml.Unmarshal, "application/xml", "application/xml; charset=utf-8", xmlBody},
+		{"xml instead of json", "foo.json", json.Unmarshal, "application/json", "text/xml", xmlBody},
+		{"json instead of xml", "foo.xml", xml.Unmarshal, "application/xml", "application/json", jsonBody},
+		{"unknown type", "foo.json", json.Unmarshal, "application/json", "text/plain", jsonBody},
+		{"missing type", "foo.xml", xml.Unmarshal, "application/xml", "", xmlBody},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.expectAccept, r.Header.Get("Accept"))
+				w.Header()["Content-Type"] = []string{tc.contentType}
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer s.Close()
+
+			var res struct {
+				ID string `xml:"id" json:"id"`
+			}
+			h := httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+			err := h.Get(context.Background(), tc.endpoint, tc.decoder, url.Values{}, &res)
+			assert.Nil(t, err)
+			assert.Equal(t, "SampleID", res.ID)
+		})
+	}
+}
+
+func BenchmarkHttpClient_Get(b *testing.B) {
+	page := "<response><reviews>" + strings.Repeat(`<review><id>1</id><rating>5</rating><body>A story of survival.</body><book><id>50</id><title>Hatchet</title><authors><author><id>18</id><name>Gary Paulsen</name></author></authors></book></review>`, reviewsPerPage) + "</reviews></response>"
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(page))
+	}))
+	defer s.Close()
+
+	h := httpClient{Client: s.Client(), APIRoot: s.URL}
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var r struct {
+				Reviews []responses.Review `xml:"reviews>review"`
+			}
+			if err := h.Get(context.Background(), "review/list/1.xml", xml.Unmarshal, url.Values{}, &r); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestHttpClient_GetStatusError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer s.Close()
+
+	h := httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+	err := h.Get(context.Background(), "foo/bar", xml.Unmarshal, url.Values{}, nil)
+	assert.Equal(t, &StatusError{Code: http.StatusBadGateway}, err)
+	assert.EqualError(t, err, "unexpected response code: 502")
+}
@@ -0,0 +1,237 @@
+package goodreads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KyleBanks/goodreads/responses"
+)
+
+// maxReviewCountsBatch is the number of ISBNs book.review_counts accepts in
+// a single request.
+const maxReviewCountsBatch = 1000
+
+// countBatcher coalesces BookReviewCounts calls made within a window into a
+// single request, and hands each caller the counts for its own ISBNs.
+type countBatcher struct {
+	window time.Duration
+	fetch  func(context.Context, []string) ([]responses.ReviewCounts, error)
+
+	mu      sync.Mutex
+	pending *countBatch
+}
+
+type countBatch struct {
+	isbns []string
+	done  chan struct{}
+
+	counts []responses.ReviewCounts
+	err    error
+}
+
+func (b *countBatcher) counts(isbns []string) ([]responses.ReviewCounts, error) {
+	b.mu.Lock()
+	if b.pending == nil || len(b.pending.isbns)+len(isbns) > maxReviewCountsBatch {
+		batch := &countBatch{done: make(chan struct{})}
+		b.pending = batch
+		time.AfterFunc(b.window, func() { b.flush(batch) })
+	}
+	batch := b.pending
+	batch.isbns = append(batch.isbns, isbns...)
+	b.mu.Unlock()
+
+	<-batch.done
+	if batch.err != nil {
+		return nil, batch.err
+	}
+
+	var counts []responses.ReviewCounts
+	seen := make(map[int]bool)
+	for _, isbn := range isbns {
+		isbn = compactISBN(isbn)
+		for _, rc := range batch.counts {
+			if (compactISBN(rc.ISBN) == isbn || compactISBN(rc.ISBN13) == isbn) && !seen[rc.ID] {
+				seen[rc.ID] = true
+				counts = append(counts, rc)
+			}
+		}
+	}
+	// Unbatched, a request for only unknown ISBNs is answered with a 404.
+	if len(counts) == 0 {
+		return nil, ErrNotFound
+	}
+	return counts, nil
+}
+
+func (b *countBatcher) flush(batch *countBatch) {
+	b.mu.Lock()
+	if b.pending == batch {
+		b.pending = nil
+	}
+	b.mu.Unlock()
+
+	seen := make(map[string]bool, len(batch.isbns))
+	var isbns []string
+	for _, isbn := range batch.isbns {
+		isbn = compactISBN(isbn)
+		if !seen[isbn] {
+			seen[isbn] = true
+			isbns = append(isbns, isbn)
+		}
+	}
+
+	batch.counts, batch.err = b.fetch(context.Background(), isbns)
+	close(batch.done)
+}
+
+// maxConcurrentRequests bounds the number of requests a batch method has in
+// flight at once.
+const maxConcurrentRequests = 4
+
+// fetchConcurrently calls fetch for each of n items, with at most
+// maxConcurrentRequests calls running at a time, and returns the error of
+// each call by index. Items not yet started when ctx is done fail with
+// ctx.Err().
+func fetchConcurrently(ctx context.Context, n int, fetch func(ctx context.Context, i int) error) []error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, maxConcurrentRequests)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			errs[i] = fetch(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// BatchError is returned by the batch methods when some of the items could
+// not be fetched. The results of the others are still returned.
+type BatchError struct {
+	// Errors holds the error for each failed ID.
+	Errors map[string]error
+
+	ids []string
+}
+
+func newBatchError(ids []string, errs []error) error {
+	b := &BatchError{Errors: make(map[string]error)}
+	for i, err := range errs {
+		if err != nil {
+			b.Errors[ids[i]] = err
+			b.ids = append(b.ids, ids[i])
+		}
+	}
+	if len(b.ids) == 0 {
+		return nil
+	}
+	return b
+}
+
+func (b *BatchError) Error() string {
+	msgs := make([]string, len(b.ids))
+	for i, id := range b.ids {
+		msgs[i] = fmt.Sprintf("%s: %v", id, b.Errors[id])
+	}
+	return fmt.Sprintf("%d of the batch failed: %s", len(b.ids), strings.Join(msgs, "; "))
+}
+
+// BatchOption customizes a single call of a batch method, such as
+// AuthorShowBatch or ExportLibraryCSV.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	load func() []string
+	save func([]string)
+}
+
+// WithCheckpoint makes a batch method resumable. load returns the IDs of
+// the items completed by an earlier run, which are skipped, and save is
+// called with every completed ID, those loaded included, each time more
+// items complete, so that an interrupted run can be resumed from where it
+// stopped. Calls to save are not concurrent.
+func WithCheckpoint(load func() []string, save func([]string)) BatchOption {
+	return func(o *batchOptions) {
+		o.load, o.save = load, save
+	}
+}
+
+// checkpoint tracks the items a batch has completed. A nil checkpoint skips
+// nothing and saves nothing.
+type checkpoint struct {
+	save func([]string)
+
+	mu   sync.Mutex
+	ids  []string
+	done map[string]bool
+}
+
+func newCheckpoint(opts []BatchOption) *checkpoint {
+	var o batchOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.load == nil && o.save == nil {
+		return nil
+	}
+
+	cp := &checkpoint{save: o.save, done: make(map[string]bool)}
+	if o.load != nil {
+		for _, id := range o.load() {
+			if !cp.done[id] {
+				cp.done[id] = true
+				cp.ids = append(cp.ids, id)
+			}
+		}
+	}
+	return cp
+}
+
+// completed reports whether an earlier run, or this one, completed id.
+func (cp *checkpoint) completed(id string) bool {
+	if cp == nil {
+		return false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.done[id]
+}
+
+// resumed reports whether any items were completed before.
+func (cp *checkpoint) resumed() bool {
+	if cp == nil {
+		return false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.ids) > 0
+}
+
+// complete records ids as completed and saves the checkpoint.
+func (cp *checkpoint) complete(ids ...string) {
+	if cp == nil || len(ids) == 0 {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	for _, id := range ids {
+		if !cp.done[id] {
+			cp.done[id] = true
+			cp.ids = append(cp.ids, id)
+		}
+	}
+	if cp.save != nil {
+		cp.save(append([]string(nil), cp.ids...))
+	}
+}
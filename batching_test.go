@@ -0,0 +1,171 @@
+package goodreads
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCountBatching(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isbns := strings.Split(r.URL.Query().Get("isbns"), ",")
+		sort.Strings(isbns)
+		mu.Lock()
+		requests = append(requests, strings.Join(isbns, ","))
+		mu.Unlock()
+
+		var books []string
+		for i, isbn := range isbns {
+			books = append(books, fmt.Sprintf(`{"id": %d, "isbn13": "%s", "ratings_count": %d}`, i+1, isbn, i+1))
+		}
+		_, _ = fmt.Fprintf(w, `{"books": [%s]}`, strings.Join(books, ","))
+	}))
+	defer s.Close()
+
+	c := NewClient(testAPIKey, WithCountBatching(50*time.Millisecond))
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+
+	calls := [][]string{{"1"}, {"2", "3"}, {"3", "4"}}
+	var wg sync.WaitGroup
+	for _, isbns := range calls {
+		wg.Add(1)
+		go func(isbns []string) {
+			defer wg.Done()
+			counts, err := c.BookReviewCounts(isbns)
+			assert.Nil(t, err)
+			var got []string
+			for _, rc := range counts {
+				got = append(got, rc.ISBN13)
+			}
+			assert.Equal(t, isbns, got)
+		}(isbns)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []string{"1,2,3,4"}, requests)
+}
+
+func TestWithCountBatching_hyphenatedISBN(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"books": [{"id": 1, "isbn": "140007877x", "isbn13": "9781400078776", "ratings_count": 5}]}`)
+	}))
+	defer s.Close()
+
+	c := NewClient(testAPIKey, WithCountBatching(10*time.Millisecond))
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+
+	for _, isbn := range []string{"978-1400078776", "140007877X"} {
+		counts, err := c.BookReviewCounts([]string{isbn})
+		assert.Nil(t, err)
+		if assert.Len(t, counts, 1, isbn) {
+			assert.Equal(t, 1, counts[0].ID)
+		}
+	}
+}
+
+func TestWithCheckpoint_AuthorShowBatch(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/author/show/")
+		mu.Lock()
+		requested = append(requested, id)
+		mu.Unlock()
+		if id == "3" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, "<response><author><id>%s</id></author></response>", id)
+	}))
+	defer s.Close()
+	c := &Client{APIKey: testAPIKey, httpClient: &httpClient{Client: http.DefaultClient, APIRoot: s.URL}}
+
+	var saves [][]string
+	load := func() []string { return []string{"1"} }
+	save := func(ids []string) { saves = append(saves, ids) }
+
+	authors, err := c.AuthorShowBatch(context.Background(), []string{"1", "2", "3", "4"}, WithCheckpoint(load, save))
+	assert.Equal(t, ErrNotFound, err.(*BatchError).Errors["3"])
+	assert.Len(t, err.(*BatchError).Errors, 1)
+	assert.Nil(t, authors[0])
+	assert.Equal(t, "2", authors[1].ID)
+	assert.Nil(t, authors[2])
+	assert.Equal(t, "4", authors[3].ID)
+
+	sort.Strings(requested)
+	assert.Equal(t, []string{"2", "3", "4"}, requested)
+	if assert.Len(t, saves, 2) {
+		assert.Len(t, saves[0], 2)
+		last := saves[1]
+		sort.Strings(last)
+		assert.Equal(t, []string{"1", "2", "4"}, last)
+	}
+}
+
+func TestWithCheckpoint_ExportLibraryCSV(t *testing.T) {
+	c, done := newRoutedTestClient(t, reviewListPage("1", 1,
+		`<review><id>r1</id><book><id>50</id><title>Hatchet</title></book></review>`,
+		`<review><id>r2</id><book><id>51</id><title>The River</title></book></review>`,
+	))
+	defer done()
+
+	var saved []string
+	save := func(ids []string) { saved = ids }
+
+	var buf bytes.Buffer
+	err := c.ExportLibraryCSV(context.Background(), "1", &buf, WithCheckpoint(func() []string { return []string{"r1"} }, save))
+	assert.Nil(t, err)
+	assert.Equal(t, "51,The River,,,,0,0.00,,0,0,,,,0\n", buf.String())
+	assert.Equal(t, []string{"r1", "r2"}, saved)
+
+	buf.Reset()
+	err = c.ExportLibraryCSV(context.Background(), "1", &buf, WithCheckpoint(nil, save))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "Book Id,"))
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+}
+
+func TestWithCountBatching_unknownISBN(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isbns := strings.Split(r.URL.Query().Get("isbns"), ",")
+		sort.Strings(isbns)
+		mu.Lock()
+		requests = append(requests, strings.Join(isbns, ","))
+		mu.Unlock()
+		_, _ = fmt.Fprint(w, `{"books": [{"id": 1, "isbn13": "9781400078776"}]}`)
+	}))
+	defer s.Close()
+
+	c := NewClient(testAPIKey, WithCountBatching(50*time.Millisecond))
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+
+	calls := map[string]error{"978-1400078776": nil, "9781400078776": nil, "0000000000": ErrNotFound}
+	var wg sync.WaitGroup
+	for isbn, expect := range calls {
+		wg.Add(1)
+		go func(isbn string, expect error) {
+			defer wg.Done()
+			counts, err := c.BookReviewCounts([]string{isbn})
+			assert.Equal(t, expect, err, isbn)
+			if expect == nil {
+				assert.Len(t, counts, 1, isbn)
+			}
+		}(isbn, expect)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []string{"0000000000,9781400078776"}, requests)
+}
@@ -0,0 +1,68 @@
+package goodreads
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache stores the results a Client reuses between calls, such as the work
+// IDs of books. Implementations must be safe for concurrent use.
+//
+// The default is a MemoryCache, which is lost when the process exits. A
+// persistent Cache, backed by Redis or BoltDB for example, can be set with
+// WithCacheBackend to keep results across restarts.
+type Cache interface {
+	// Get returns the value stored for key, and whether one was found and
+	// has not expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores a value for key, expiring after ttl, or never if ttl is 0.
+	Set(key string, val []byte, ttl time.Duration)
+}
+
+// MemoryCache is an in-memory Cache. The zero value is ready to use.
+type MemoryCache struct {
+	m sync.Map // key -> memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	val     []byte
+	expires time.Time
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	v, ok := m.m.Load(key)
+	if !ok {
+		return nil, false
+	}
+	e := v.(memoryCacheEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		m.m.Delete(key)
+		return nil, false
+	}
+	return e.val, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, val []byte, ttl time.Duration) {
+	e := memoryCacheEntry{val: val}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+	m.m.Store(key, e)
+}
+
+// cache returns the Client's Cache, which is its own MemoryCache unless
+// another was set with WithCacheBackend.
+func (c *Client) cache() Cache {
+	if c.cacheBackend != nil {
+		return c.cacheBackend
+	}
+	return &c.memoryCache
+}
+
+// workIDCacheKey is the Cache key of the work ID of a book.
+func workIDCacheKey(bookID string) string {
+	return "work_id:" + bookID
+}
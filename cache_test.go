@@ -0,0 +1,29 @@
+package goodreads
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	var m MemoryCache
+
+	_, ok := m.Get("a")
+	assert.False(t, ok)
+
+	m.Set("a", []byte("1"), 0)
+	m.Set("b", []byte("2"), time.Hour)
+	m.Set("c", []byte("3"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	v, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+	v, ok = m.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("2"), v)
+	_, ok = m.Get("c")
+	assert.False(t, ok)
+}
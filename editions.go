@@ -0,0 +1,182 @@
+package goodreads
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/KyleBanks/goodreads/responses"
+)
+
+// walkEditions pages through every edition of a work, calling fn with each
+// page until the editions are exhausted or fn returns an error.
+//
+// Paging stops at the last page the pagination reports, at an empty page,
+// or at a page that repeats the one before it, as a server without
+// pagination may serve its last page again for pages past the end.
+func (c *Client) walkEditions(ctx context.Context, workID string, fn func([]responses.Book) error) error {
+	var prevFirstID string
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		editions, p, err := c.workEditions(ctx, workID, page)
+		if err != nil {
+			return err
+		}
+		if len(editions) == 0 || editions[0].ID == prevFirstID {
+			return nil
+		}
+		prevFirstID = editions[0].ID
+		if err := fn(editions); err != nil {
+			return err
+		}
+		if p.Total > 0 && p.End >= p.Total {
+			return nil
+		}
+	}
+}
+
+// WorkEditionByFormat returns the most rated edition of a work in the given
+// format, such as "Audiobook", "Kindle Edition" or "Hardcover". Formats are
+// matched case-insensitively. ErrNotFound is returned if no edition has
+// the format.
+//
+// Editions are listed with WorkEditions, so this only works with API keys
+// that Goodreads has granted access to work.editions.
+func (c *Client) WorkEditionByFormat(ctx context.Context, workID, format string) (*responses.Book, error) {
+	format = strings.TrimSpace(format)
+
+	var best *responses.Book
+	err := c.walkEditions(ctx, workID, func(editions []responses.Book) error {
+		for i, e := range editions {
+			if !strings.EqualFold(strings.TrimSpace(e.Format), format) {
+				continue
+			}
+			if best == nil || e.RatingsCount > best.RatingsCount {
+				best = &editions[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if best == nil {
+		return nil, ErrNotFound
+	}
+	return best, nil
+}
+
+// WorkEditionsByLanguage returns every edition of a work, grouped by their
+// language code, such as "eng" or "spa". Editions without a language code
+// are grouped under "".
+//
+// Like WorkEditions, which it pages through, this needs an API key with
+// access to work.editions.
+func (c *Client) WorkEditionsByLanguage(ctx context.Context, workID string) (map[string][]responses.Book, error) {
+	byLanguage := make(map[string][]responses.Book)
+	err := c.walkEditions(ctx, workID, func(editions []responses.Book) error {
+		for _, e := range editions {
+			lang := strings.TrimSpace(e.LanguageCode)
+			byLanguage[lang] = append(byLanguage[lang], e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return byLanguage, nil
+}
+
+// GroupEditionsByWork fetches several books, a few at a time, and groups
+// them by the ID of their work, so that editions of the same book share a
+// group. Books are fetched once however often they are given, and books
+// whose work is not reported are grouped under "". The work IDs learned
+// are cached for IDToWorkID.
+//
+// If any books fail, the error is a *BatchError and the books that were
+// fetched are still grouped.
+func (c *Client) GroupEditionsByWork(ctx context.Context, bookIDs []string) (map[string][]responses.Book, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range bookIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	books := make([]*responses.Book, len(ids))
+	errs := fetchConcurrently(ctx, len(ids), func(ctx context.Context, i int) error {
+		b, err := c.bookShow(ctx, ids[i])
+		books[i] = b
+		return err
+	})
+
+	byWork := make(map[string][]responses.Book)
+	for _, b := range books {
+		if b == nil {
+			continue
+		}
+		workID := ""
+		if b.Work.ID != 0 {
+			workID = strconv.Itoa(b.Work.ID)
+			c.cache().Set(workIDCacheKey(b.ID), []byte(workID), 0)
+		}
+		byWork[workID] = append(byWork[workID], *b)
+	}
+	return byWork, newBatchError(ids, errs)
+}
+
+// WorkSimilar returns the books that readers of a work also enjoyed, at most
+// one per work and up to limit books. A limit of zero or less returns all of
+// them.
+//
+// The similar books are those of the work's best edition, found through the
+// first listed edition of the work. Listing it requires an API key that has
+// been granted access to work.editions; with an ordinary key this fails.
+func (c *Client) WorkSimilar(ctx context.Context, workID string, limit int) ([]responses.Book, error) {
+	workID, err := parseID(workID)
+	if err != nil {
+		return nil, err
+	}
+
+	editions, _, err := c.workEditions(ctx, workID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(editions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	b, err := c.bookShow(ctx, editions[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	if best := strconv.Itoa(b.Work.BestBookID); b.Work.BestBookID != 0 && best != b.ID {
+		if b, err = c.bookShow(ctx, best); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := map[string]bool{workID: true}
+	var similar []responses.Book
+	for _, s := range b.SimilarBooks {
+		key := "book:" + s.ID
+		if s.Work.ID != 0 {
+			key = strconv.Itoa(s.Work.ID)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		similar = append(similar, s)
+		if limit > 0 && len(similar) == limit {
+			break
+		}
+	}
+	return similar, nil
+}
@@ -0,0 +1,176 @@
+package goodreads
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/KyleBanks/goodreads/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+func editionsPage(page int, response string) decodeTestCase {
+	return decodeTestCase{
+		expectURL: fmt.Sprintf("/work/editions/1?format=xml&key=%s&page=%d", testAPIKey, page),
+		response:  response,
+	}
+}
+
+func TestClient_WorkEditionByFormat(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		editionsPage(1, `<response><editions>
+			<book><id>1</id><format>Hardcover</format><ratings_count>10</ratings_count></book>
+			<book><id>2</id><format>Audiobook</format><ratings_count>5</ratings_count></book>
+		</editions></response>`),
+		editionsPage(2, `<response><editions>
+			<book><id>3</id><format>audiobook</format><ratings_count>7</ratings_count></book>
+			<book><id>4</id><format>Paperback</format><ratings_count>70</ratings_count></book>
+		</editions></response>`),
+		editionsPage(3, `<response><editions></editions></response>`),
+	)
+	defer done()
+
+	b, err := c.WorkEditionByFormat(context.Background(), "1", "Audiobook")
+	assert.Nil(t, err)
+	assert.Equal(t, "3", b.ID)
+
+	b, err = c.WorkEditionByFormat(context.Background(), "1", "Kindle Edition")
+	assert.Nil(t, b)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestClient_WalkEditionsStopsAtLastPage(t *testing.T) {
+	testCases := []struct {
+		name         string
+		pagination   [2]string
+		wantRequests int
+	}{
+		{"with pagination", [2]string{`start="1" end="1" total="2"`, `start="2" end="2" total="2"`}, 2},
+		// The repeated last page is what ends the walk.
+		{"without pagination", [2]string{"", ""}, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int32
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				// Pages past the end are answered with the last page.
+				if r.URL.Query().Get("page") == "1" {
+					_, _ = fmt.Fprintf(w, `<response><editions %s><book><id>1</id></book></editions></response>`, tc.pagination[0])
+					return
+				}
+				_, _ = fmt.Fprintf(w, `<response><editions %s><book><id>2</id></book></editions></response>`, tc.pagination[1])
+			}))
+			defer s.Close()
+			c := &Client{APIKey: testAPIKey, httpClient: &httpClient{Client: http.DefaultClient, APIRoot: s.URL}}
+
+			var ids []string
+			err := c.walkEditions(context.Background(), "1", func(editions []responses.Book) error {
+				for _, e := range editions {
+					ids = append(ids, e.ID)
+				}
+				return nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"1", "2"}, ids)
+			assert.Equal(t, int32(tc.wantRequests), atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestClient_WorkEditionsByLanguage(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		editionsPage(1, `<response><editions>
+			<book><id>1</id><language_code>eng</language_code></book>
+			<book><id>2</id><language_code>spa</language_code></book>
+		</editions></response>`),
+		editionsPage(2, `<response><editions>
+			<book><id>3</id><language_code>eng</language_code></book>
+			<book><id>4</id></book>
+		</editions></response>`),
+		editionsPage(3, `<response><editions></editions></response>`),
+	)
+	defer done()
+
+	byLanguage, err := c.WorkEditionsByLanguage(context.Background(), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]responses.Book{
+		"eng": {{ID: "1", LanguageCode: "eng"}, {ID: "3", LanguageCode: "eng"}},
+		"spa": {{ID: "2", LanguageCode: "spa"}},
+		"":    {{ID: "4"}},
+	}, byLanguage)
+}
+
+func TestClient_GroupEditionsByWork(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/1.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>1</id><work><id>100</id></work></book></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/2.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>2</id><work><id>200</id></work></book></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/3.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>3</id><work><id>100</id></work></book></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/4.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>4</id></book></response>`,
+		},
+	)
+	defer done()
+
+	byWork, err := c.GroupEditionsByWork(context.Background(), []string{"1", "2", "3", "1", "4", "404"})
+	if be, ok := err.(*BatchError); assert.True(t, ok) {
+		assert.Equal(t, map[string]error{"404": ErrNotFound}, be.Errors)
+	}
+	ids := make(map[string][]string)
+	for w, books := range byWork {
+		for _, b := range books {
+			ids[w] = append(ids[w], b.ID)
+		}
+	}
+	assert.Equal(t, map[string][]string{"100": {"1", "3"}, "200": {"2"}, "": {"4"}}, ids)
+
+	// The work IDs are cached, so no request is made.
+	w, err := c.IDToWorkID([]string{"3"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"100"}, w)
+}
+
+func TestClient_WorkSimilar(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		editionsPage(1, `<response><editions><book><id>2</id></book></editions></response>`),
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/2.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>2</id><work><id>1</id><best_book_id>3</best_book_id></work></book></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/3.xml?key=%s", testAPIKey),
+			response: `<response><book><id>3</id><work><id>1</id><best_book_id>3</best_book_id></work>
+				<similar_books>
+					<book><id>10</id><work><id>100</id></work></book>
+					<book><id>11</id><work><id>100</id></work></book>
+					<book><id>4</id><work><id>1</id></work></book>
+					<book><id>12</id><work><id>120</id></work></book>
+					<book><id>13</id><work><id>130</id></work></book>
+				</similar_books>
+			</book></response>`,
+		},
+	)
+	defer done()
+
+	similar, err := c.WorkSimilar(context.Background(), "1", 2)
+	assert.Nil(t, err)
+	var ids []string
+	for _, b := range similar {
+		ids = append(ids, b.ID)
+	}
+	assert.Equal(t, []string{"10", "12"}, ids)
+}
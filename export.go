@@ -0,0 +1,343 @@
+package goodreads
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/KyleBanks/goodreads/responses"
+)
+
+// libraryCSVHeader lists the columns written by ExportLibraryCSV, following
+// the naming of the Goodreads library export.
+var libraryCSVHeader = []string{
+	"Book Id", "Title", "Author", "ISBN", "ISBN13", "My Rating",
+	"Average Rating", "Publisher", "Number of Pages", "Year Published",
+	"Date Read", "Date Added", "My Review", "Read Count",
+}
+
+// ExportLibraryCSV writes every review on a user's shelves to w as CSV, one
+// row per review, preceded by a header row.
+//
+// Rows are written and flushed as each page of reviews is fetched, so memory
+// use does not grow with the size of the library. If writing fails, for
+// example because the reader went away, no further pages are fetched and
+// the write error is returned.
+//
+// With WithCheckpoint, the IDs of the reviews written are saved after each
+// page. A resumed export appends only the rows not yet written, without a
+// second header, so w should append to the output of the earlier run.
+func (c *Client) ExportLibraryCSV(ctx context.Context, userID string, w io.Writer, opts ...BatchOption) error {
+	cp := newCheckpoint(opts)
+	cw := csv.NewWriter(w)
+	if !cp.resumed() {
+		if err := cw.Write(libraryCSVHeader); err != nil {
+			return err
+		}
+		// An empty library has no pages to flush the header with.
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+
+	return c.walkReviews(ctx, userID, "", "", "", func(reviews []responses.Review) error {
+		var written []string
+		for _, r := range reviews {
+			if cp.completed(r.ID) {
+				continue
+			}
+			if err := cw.Write(libraryCSVRow(r)); err != nil {
+				return err
+			}
+			written = append(written, r.ID)
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		cp.complete(written...)
+		return nil
+	})
+}
+
+func libraryCSVRow(r responses.Review) []string {
+	var author string
+	if len(r.Book.Authors) > 0 {
+		author = r.Book.Authors[0].Name
+	}
+
+	return []string{
+		r.Book.ID,
+		r.Book.Title,
+		author,
+		r.Book.ISBN,
+		r.Book.ISBN13,
+		strconv.Itoa(r.Rating),
+		fmt.Sprintf("%.2f", r.Book.AverageRating),
+		r.Book.Publisher,
+		strconv.Itoa(r.Book.NumPages),
+		strconv.Itoa(r.Book.PublicationYear),
+		r.ReadAt,
+		r.DateAdded,
+		r.Body,
+		strconv.Itoa(r.ReadCount),
+	}
+}
+
+// ShelfEntry is the JSON shape of a review returned by ShelfJSON. Its field
+// names are stable, dates are RFC 3339 and omitted when unknown, and ratings
+// are numbers.
+type ShelfEntry struct {
+	BookID        string        `json:"book_id"`
+	Title         string        `json:"title"`
+	ISBN          string        `json:"isbn,omitempty"`
+	ISBN13        string        `json:"isbn13,omitempty"`
+	Authors       []ShelfAuthor `json:"authors"`
+	ImageURL      string        `json:"image_url,omitempty"`
+	NumPages      int           `json:"num_pages,omitempty"`
+	AverageRating float64       `json:"average_rating"`
+	Rating        int           `json:"rating"`
+	Review        string        `json:"review,omitempty"`
+	DateAdded     *time.Time    `json:"date_added,omitempty"`
+	StartedAt     *time.Time    `json:"started_at,omitempty"`
+	ReadAt        *time.Time    `json:"read_at,omitempty"`
+}
+
+// ShelfAuthor is an author of a ShelfEntry. Role is empty for the primary
+// author, or names a contribution such as "Illustrator".
+type ShelfAuthor struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role,omitempty"`
+}
+
+// ShelfJSON returns the reviews on a user's shelf as a JSON array of
+// ShelfEntry, ready to be served to a frontend. An empty shelf lists every
+// shelf, and an empty result is encoded as [].
+func (c *Client) ShelfJSON(ctx context.Context, userID, shelf string) ([]byte, error) {
+	entries := []ShelfEntry{}
+	err := c.walkReviews(ctx, userID, shelf, "", "", func(reviews []responses.Review) error {
+		for _, r := range reviews {
+			entries = append(entries, shelfEntry(r))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(entries)
+}
+
+func shelfEntry(r responses.Review) ShelfEntry {
+	e := ShelfEntry{
+		BookID:   r.Book.ID,
+		Title:    r.Book.Title,
+		ISBN:     r.Book.ISBN,
+		ISBN13:   r.Book.ISBN13,
+		Authors:  make([]ShelfAuthor, len(r.Book.Authors)),
+		ImageURL: r.Book.ImageURL,
+		NumPages: r.Book.NumPages,
+		Rating:   r.Rating,
+		Review:   r.Body,
+	}
+	for i, a := range r.Book.Authors {
+		e.Authors[i] = ShelfAuthor{ID: a.ID, Name: a.Name, Role: a.Role}
+	}
+	e.AverageRating = decimalFloat(r.Book.AverageRating)
+	if t, ok := r.DateAddedTime(); ok {
+		e.DateAdded = &t
+	}
+	if t, ok := r.StartedAtTime(); ok {
+		e.StartedAt = &t
+	}
+	if t, ok := r.ReadAtTime(); ok {
+		e.ReadAt = &t
+	}
+	return e
+}
+
+// decimalFloat widens f through its decimal form, so that 3.71 is not
+// widened to 3.7100000381469727.
+func decimalFloat(f float32) float64 {
+	d, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'f', -1, 32), 64)
+	return d
+}
+
+// BibliographyEntry is a book written by ExportBibliography.
+type BibliographyEntry struct {
+	BookID          string  `json:"book_id"`
+	WorkID          string  `json:"work_id,omitempty"`
+	Title           string  `json:"title"`
+	ISBN            string  `json:"isbn,omitempty"`
+	ISBN13          string  `json:"isbn13,omitempty"`
+	Publisher       string  `json:"publisher,omitempty"`
+	PublicationYear int     `json:"publication_year,omitempty"`
+	NumPages        int     `json:"num_pages,omitempty"`
+	AverageRating   float64 `json:"average_rating"`
+	RatingsCount    int     `json:"ratings_count"`
+	Link            string  `json:"link"`
+}
+
+// bibliographyCSVHeader lists the columns written by ExportBibliography in
+// the "csv" format.
+var bibliographyCSVHeader = []string{
+	"Book Id", "Work Id", "Title", "ISBN", "ISBN13", "Publisher",
+	"Year Published", "Number of Pages", "Average Rating", "Ratings Count",
+	"Link",
+}
+
+// ExportBibliography writes every book of an author to w, in the "json"
+// format as an array of BibliographyEntry, or in the "csv" format with a
+// header row. Only the first edition listed of each work is written.
+//
+// Books are written as each page of the author's books is fetched, so memory
+// use does not grow with the size of the bibliography. If writing fails, no
+// further pages are fetched and the write error is returned.
+func (c *Client) ExportBibliography(ctx context.Context, authorID string, w io.Writer, format string) error {
+	var write func(BibliographyEntry) error
+	var flush, finish func() error
+	switch format {
+	case "json":
+		n := 0
+		write = func(e BibliographyEntry) error {
+			b, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			sep := ","
+			if n == 0 {
+				sep = "["
+			}
+			n++
+			_, err = io.WriteString(w, sep+string(b))
+			return err
+		}
+		flush = func() error { return nil }
+		finish = func() error {
+			end := "]"
+			if n == 0 {
+				end = "[]"
+			}
+			_, err := io.WriteString(w, end+"\n")
+			return err
+		}
+	case "csv":
+		cw := csv.NewWriter(w)
+		write = func(e BibliographyEntry) error {
+			return cw.Write(bibliographyCSVRow(e))
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+		finish = flush
+		if err := cw.Write(bibliographyCSVHeader); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported bibliography format %q", format)
+	}
+
+	seen := make(map[string]bool)
+	err := c.walkAuthorBooks(ctx, authorID, func(books []responses.AuthorBook) error {
+		ids := make([]string, len(books))
+		for i, b := range books {
+			ids[i] = b.ID
+		}
+		workIDs, err := c.idToWorkID(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for i, b := range books {
+			if workIDs[i] != "" {
+				if seen[workIDs[i]] {
+					continue
+				}
+				seen[workIDs[i]] = true
+			}
+			if err := write(bibliographyEntry(b, workIDs[i])); err != nil {
+				return err
+			}
+		}
+		return flush()
+	})
+	if err != nil {
+		return err
+	}
+	return finish()
+}
+
+func bibliographyEntry(b responses.AuthorBook, workID string) BibliographyEntry {
+	return BibliographyEntry{
+		BookID:          b.ID,
+		WorkID:          workID,
+		Title:           b.Title,
+		ISBN:            b.ISBN,
+		ISBN13:          b.ISBN13,
+		Publisher:       b.Publisher,
+		PublicationYear: b.PublicationYear,
+		NumPages:        b.NumPages,
+		AverageRating:   decimalFloat(b.AverageRating),
+		RatingsCount:    b.RatingsCount,
+		Link:            b.GoodreadsURL(),
+	}
+}
+
+func bibliographyCSVRow(e BibliographyEntry) []string {
+	return []string{
+		e.BookID,
+		e.WorkID,
+		e.Title,
+		e.ISBN,
+		e.ISBN13,
+		e.Publisher,
+		strconv.Itoa(e.PublicationYear),
+		strconv.Itoa(e.NumPages),
+		strconv.FormatFloat(e.AverageRating, 'f', 2, 64),
+		strconv.Itoa(e.RatingsCount),
+		e.Link,
+	}
+}
+
+// walkAuthorBooks calls fn with each page of an author's books, until the
+// last page has been fetched or fn returns an error.
+func (c *Client) walkAuthorBooks(ctx context.Context, authorID string, fn func([]responses.AuthorBook) error) error {
+	authorID, err := parseID(authorID)
+	if err != nil {
+		return err
+	}
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		v := c.defaultValues()
+		v.Set("page", strconv.Itoa(page))
+		var r struct {
+			Books struct {
+				responses.Pagination
+				Books []responses.AuthorBook `xml:"book"`
+			} `xml:"author>books"`
+		}
+		err := c.get(ctx, "AuthorBooks", fmt.Sprintf("author/list/%s", authorID), xml.Unmarshal, v, &r)
+		if err != nil {
+			return err
+		}
+		if len(r.Books.Books) == 0 {
+			return nil
+		}
+		if err := fn(r.Books.Books); err != nil {
+			return err
+		}
+		if p := r.Books.Pagination; p.Total > 0 && p.End >= p.Total {
+			return nil
+		}
+	}
+}
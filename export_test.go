@@ -0,0 +1,183 @@
+package goodreads
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func reviewListPage(userID string, page int, reviews ...string) decodeTestCase {
+	return decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/%s.xml?key=%s&page=%d&per_page=200&v=2", userID, testAPIKey, page),
+		response:  fmt.Sprintf("<response><reviews>%s</reviews></response>", strings.Join(reviews, "")),
+	}
+}
+
+func TestClient_ExportLibraryCSV(t *testing.T) {
+	c, done := newRoutedTestClient(t, reviewListPage("1", 1,
+		`<review><id>1</id><rating>5</rating><read_at>Sat Jun 01 00:00:00 -0700 2019</read_at><read_count>1</read_count>
+			<book><id>50</id><title>Hatchet</title><isbn>0689840926</isbn><average_rating>3.71</average_rating><num_pages>195</num_pages>
+			<authors><author><name>Gary Paulsen</name></author></authors></book></review>`,
+		`<review><id>2</id><book><id>51</id><title>Brian's "Winter"</title></book><body>Cold, but good.</body></review>`,
+	))
+	defer done()
+
+	var buf bytes.Buffer
+	err := c.ExportLibraryCSV(context.Background(), "1", &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, `Book Id,Title,Author,ISBN,ISBN13,My Rating,Average Rating,Publisher,Number of Pages,Year Published,Date Read,Date Added,My Review,Read Count
+50,Hatchet,Gary Paulsen,0689840926,,5,3.71,,195,0,Sat Jun 01 00:00:00 -0700 2019,,,1
+51,"Brian's ""Winter""",,,,0,0.00,,0,0,,,"Cold, but good.",0
+`, buf.String())
+}
+
+func TestClient_ExportLibraryCSV_empty(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&v=2", testAPIKey),
+		response:  `<response><reviews start="0" end="0" total="0"></reviews></response>`,
+	})
+	defer done()
+
+	var buf bytes.Buffer
+	err := c.ExportLibraryCSV(context.Background(), "1", &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Join(libraryCSVHeader, ",")+"\n", buf.String())
+}
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("client went away")
+}
+
+func TestClient_ExportLibraryCSVWriteError(t *testing.T) {
+	full := make([]string, reviewsPerPage)
+	for i := range full {
+		full[i] = fmt.Sprintf("<review><id>%d</id><book><id>%d</id></book></review>", i, i)
+	}
+	// Only the first page is served; fetching the second would fail the
+	// test with a 404 rather than the write error.
+	c, done := newRoutedTestClient(t, reviewListPage("1", 1, full...))
+	defer done()
+
+	w := &failingWriter{}
+	err := c.ExportLibraryCSV(context.Background(), "1", w)
+	assert.EqualError(t, err, "client went away")
+	assert.Equal(t, 1, w.writes)
+}
+
+func TestClient_ShelfJSON(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&shelf=read&v=2", testAPIKey),
+		response: `<response><reviews>
+			<review><rating>5</rating><read_at>Sat Jun 01 00:00:00 -0700 2019</read_at><date_added>Wed May 01 10:30:00 -0700 2019</date_added>
+				<book><id>50</id><title>Hatchet</title><average_rating>3.71</average_rating>
+				<authors><author><id>18</id><name>Gary Paulsen</name></author><author><id>19</id><name>Someone</name><role>Illustrator</role></author></authors></book></review>
+			<review><book><id>51</id><title>Brian's Winter</title></book></review>
+		</reviews></response>`,
+	})
+	defer done()
+
+	b, err := c.ShelfJSON(context.Background(), "1", "read")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+		{"book_id": "50", "title": "Hatchet", "average_rating": 3.71, "rating": 5,
+		 "authors": [{"id": "18", "name": "Gary Paulsen"}, {"id": "19", "name": "Someone", "role": "Illustrator"}],
+		 "date_added": "2019-05-01T10:30:00-07:00", "read_at": "2019-06-01T00:00:00-07:00"},
+		{"book_id": "51", "title": "Brian's Winter", "average_rating": 0, "rating": 0, "authors": []}
+	]`, string(b))
+}
+
+func TestClient_ShelfJSON_empty(t *testing.T) {
+	c, done := newRoutedTestClient(t, reviewListPage("1", 1))
+	defer done()
+
+	b, err := c.ShelfJSON(context.Background(), "1", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(b))
+}
+
+func newBibliographyTestClient(t *testing.T) (*Client, func()) {
+	return newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/author/list/18?key=%s&page=1", testAPIKey),
+			response: `<response><author><id>18</id><books start="1" end="2" total="3">
+				<book><id>50</id><title>Hatchet</title><publication_year>1987</publication_year><average_rating>3.71</average_rating><ratings_count>300</ratings_count></book>
+				<book><id>52</id><title>Hatchet (Audio)</title></book>
+			</books></author></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/author/list/18?key=%s&page=2", testAPIKey),
+			response: `<response><author><id>18</id><books start="3" end="3" total="3">
+				<book><id>51</id><title>Brian's "Winter"</title><link>https://www.goodreads.com/book/show/51.Brian_s_Winter</link></book>
+			</books></author></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/id_to_work_id/50,52?key=%s", testAPIKey),
+			response:  `<response><work-ids><item>100</item><item>100</item></work-ids></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/id_to_work_id/51?key=%s", testAPIKey),
+			response:  `<response><work-ids><item>101</item></work-ids></response>`,
+		},
+	)
+}
+
+func TestClient_ExportBibliographyJSON(t *testing.T) {
+	c, done := newBibliographyTestClient(t)
+	defer done()
+
+	var buf bytes.Buffer
+	err := c.ExportBibliography(context.Background(), "18", &buf, "json")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+		{"book_id": "50", "work_id": "100", "title": "Hatchet", "publication_year": 1987, "average_rating": 3.71,
+		 "ratings_count": 300, "link": "https://www.goodreads.com/book/show/50"},
+		{"book_id": "51", "work_id": "101", "title": "Brian's \"Winter\"", "average_rating": 0,
+		 "ratings_count": 0, "link": "https://www.goodreads.com/book/show/51.Brian_s_Winter"}
+	]`, buf.String())
+}
+
+func TestClient_ExportBibliographyCSV(t *testing.T) {
+	c, done := newBibliographyTestClient(t)
+	defer done()
+
+	var buf bytes.Buffer
+	err := c.ExportBibliography(context.Background(), "18", &buf, "csv")
+	assert.Nil(t, err)
+	assert.Equal(t, `Book Id,Work Id,Title,ISBN,ISBN13,Publisher,Year Published,Number of Pages,Average Rating,Ratings Count,Link
+50,100,Hatchet,,,,1987,0,3.71,300,https://www.goodreads.com/book/show/50
+51,101,"Brian's ""Winter""",,,,0,0,0.00,0,https://www.goodreads.com/book/show/51.Brian_s_Winter
+`, buf.String())
+}
+
+func TestClient_ExportBibliographyEmpty(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/author/list/18?key=%s&page=1", testAPIKey),
+		response:  `<response><author><id>18</id><books start="0" end="0" total="0"></books></author></response>`,
+	})
+	defer done()
+
+	var buf bytes.Buffer
+	err := c.ExportBibliography(context.Background(), "18", &buf, "json")
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestClient_ExportBibliographyUnsupportedFormat(t *testing.T) {
+	c, done := newRoutedTestClient(t)
+	defer done()
+
+	var buf bytes.Buffer
+	err := c.ExportBibliography(context.Background(), "18", &buf, "xml")
+	assert.EqualError(t, err, `unsupported bibliography format "xml"`)
+	assert.Equal(t, 0, buf.Len())
+}
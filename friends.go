@@ -0,0 +1,162 @@
+package goodreads
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/KyleBanks/goodreads/responses"
+)
+
+// walkFriends calls fn with each page of a user's friends, until the last
+// page has been fetched or fn returns an error. Goodreads only lists friends
+// to an authenticated user, see WithAccessToken.
+func (c *Client) walkFriends(ctx context.Context, userID string, fn func([]responses.User) error) error {
+	if c.accessToken == "" {
+		return ErrNoAccessToken
+	}
+	userID, err := parseID(userID)
+	if err != nil {
+		return err
+	}
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		v := c.defaultValues()
+		v.Set("format", "xml")
+		v.Set("page", strconv.Itoa(page))
+		var r struct {
+			Friends struct {
+				responses.Pagination
+				Users []responses.User `xml:"user"`
+			} `xml:"friends"`
+		}
+		err := c.get(ctx, "FriendsList", fmt.Sprintf("friend/user/%s", userID), xml.Unmarshal, v, &r)
+		if err != nil {
+			return err
+		}
+		if len(r.Friends.Users) == 0 {
+			return nil
+		}
+		if err := fn(r.Friends.Users); err != nil {
+			return err
+		}
+		if p := r.Friends.Pagination; p.Total == 0 || p.End >= p.Total {
+			return nil
+		}
+	}
+}
+
+// FollowedShelves returns the shelves of the authenticated user's friends,
+// as shelves they might want to follow, with the friend each belongs to as
+// its Owner. The Client must be configured with WithAccessToken.
+//
+// Goodreads has no endpoint for followed shelves, so these are derived
+// from the friends list and each friend's shelves. A user without friends,
+// or whose friends have no shelves, gets no shelves and no error. If any
+// friend's shelves fail, the error is a *BatchError keyed by friend ID and
+// the shelves that were fetched are still returned.
+func (c *Client) FollowedShelves(ctx context.Context) ([]responses.UserShelf, error) {
+	userID, err := c.AuthUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var friends []responses.User
+	err = c.walkFriends(ctx, userID, func(page []responses.User) error {
+		friends = append(friends, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(friends))
+	for i, f := range friends {
+		ids[i] = f.ID
+	}
+	shelves := make([][]responses.UserShelf, len(friends))
+	errs := fetchConcurrently(ctx, len(friends), func(ctx context.Context, i int) error {
+		var err error
+		shelves[i], err = c.shelvesList(ctx, ids[i])
+		return err
+	})
+
+	var all []responses.UserShelf
+	for i := range friends {
+		owner := friends[i]
+		for _, s := range shelves[i] {
+			s.Owner = &owner
+			all = append(all, s)
+		}
+	}
+	return all, newBatchError(ids, errs)
+}
+
+// friendsReviewsForBook returns the reviews a user's friends have of a
+// book. Goodreads has no endpoint for these, so each friend's review is
+// looked up in turn, a few at a time. Friends without a review of the book
+// are left out.
+func (c *Client) friendsReviewsForBook(ctx context.Context, userID, bookID string) ([]responses.Review, error) {
+	var friends []string
+	err := c.walkFriends(ctx, userID, func(page []responses.User) error {
+		for _, f := range page {
+			friends = append(friends, f.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*responses.Review, len(friends))
+	errs := fetchConcurrently(ctx, len(friends), func(ctx context.Context, i int) error {
+		r, err := c.reviewShowByUserAndBook(ctx, friends[i], bookID)
+		if err == ErrNotFound {
+			return nil
+		}
+		reviews[i] = r
+		return err
+	})
+	if err := newBatchError(friends, errs); err != nil {
+		return nil, err
+	}
+
+	var found []responses.Review
+	for _, r := range reviews {
+		if r != nil && r.ID != "" {
+			found = append(found, *r)
+		}
+	}
+	return found, nil
+}
+
+// FriendScore returns the average rating a user's friends gave a book,
+// along with the number of friends who rated it, for indicators such as
+// "your friends loved this". Every friend's rating counts equally, and
+// friends who shelved the book without rating it are left out. When no
+// friend rated the book, the score and count are both 0.
+//
+// Friends are only listed to an authenticated user, see WithAccessToken.
+// If any friend's review fails, the error is a *BatchError keyed by friend
+// ID.
+func (c *Client) FriendScore(ctx context.Context, userID, bookID string) (float64, int, error) {
+	reviews, err := c.friendsReviewsForBook(ctx, userID, bookID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	sum, count := 0, 0
+	for _, r := range reviews {
+		if r.Rating > 0 {
+			sum += r.Rating
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0, nil
+	}
+	return float64(sum) / float64(count), count, nil
+}
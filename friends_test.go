@@ -0,0 +1,132 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/KyleBanks/goodreads/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAuthedTestClient is like newRoutedTestClient, but signs its calls as
+// the user with ID 1, who can be looked up through AuthUserID.
+func newAuthedTestClient(t *testing.T, tcs ...decodeTestCase) (*Client, func()) {
+	tcs = append(tcs, decodeTestCase{
+		expectURL: fmt.Sprintf("/api/auth_user?key=%s", testAPIKey),
+		response:  `<GoodreadsResponse><user id="1"><name>Me</name></user></GoodreadsResponse>`,
+	})
+	c, done := newRoutedTestClient(t, tcs...)
+	c.apiSecret = "consumer-secret"
+	c.accessToken, c.accessSecret = "access-token", "access-secret"
+	c.httpClient.(*httpClient).Sign = c.signRequest
+	return c, done
+}
+
+func friendsPage(page int, pagination, users string) decodeTestCase {
+	return decodeTestCase{
+		expectURL: fmt.Sprintf("/friend/user/1?format=xml&key=%s&page=%d", testAPIKey, page),
+		response:  fmt.Sprintf("<GoodreadsResponse><friends %s>%s</friends></GoodreadsResponse>", pagination, users),
+	}
+}
+
+func shelvesPage(userID, shelves string) decodeTestCase {
+	return decodeTestCase{
+		expectURL: fmt.Sprintf("/shelf/list.xml?key=%s&user_id=%s", testAPIKey, userID),
+		response:  fmt.Sprintf("<GoodreadsResponse><shelves>%s</shelves></GoodreadsResponse>", shelves),
+	}
+}
+
+func TestClient_FollowedShelves(t *testing.T) {
+	c, done := newAuthedTestClient(t,
+		friendsPage(1, `start="1" end="1" total="2"`, `<user><id>2</id><name>Ann</name></user>`),
+		friendsPage(2, `start="2" end="2" total="2"`, `<user><id>3</id><name>Bob</name></user>`),
+		shelvesPage("2", `<user_shelf><id>20</id><name>sci-fi</name></user_shelf><user_shelf><id>21</id><name>read</name></user_shelf>`),
+		shelvesPage("3", `<user_shelf><id>30</id><name>poetry</name></user_shelf>`),
+	)
+	defer done()
+
+	shelves, err := c.FollowedShelves(context.Background())
+	assert.Nil(t, err)
+	var got []string
+	for _, s := range shelves {
+		got = append(got, s.Owner.Name+"/"+s.Name)
+	}
+	assert.Equal(t, []string{"Ann/sci-fi", "Ann/read", "Bob/poetry"}, got)
+}
+
+func TestClient_FollowedShelves_noFriends(t *testing.T) {
+	c, done := newAuthedTestClient(t, friendsPage(1, `start="0" end="0" total="0"`, ""))
+	defer done()
+
+	shelves, err := c.FollowedShelves(context.Background())
+	assert.Nil(t, err)
+	assert.Empty(t, shelves)
+}
+
+func TestClient_FollowedShelves_partialFailure(t *testing.T) {
+	c, done := newAuthedTestClient(t,
+		friendsPage(1, `start="1" end="2" total="2"`, `<user><id>2</id><name>Ann</name></user><user><id>3</id><name>Bob</name></user>`),
+		shelvesPage("2", `<user_shelf><id>20</id><name>sci-fi</name></user_shelf>`),
+	)
+	defer done()
+
+	shelves, err := c.FollowedShelves(context.Background())
+	var be *BatchError
+	if assert.True(t, errors.As(err, &be)) {
+		assert.Equal(t, map[string]error{"3": ErrNotFound}, be.Errors)
+	}
+	assert.Equal(t, []responses.UserShelf{{ID: "20", Name: "sci-fi", Owner: &responses.User{ID: "2", Name: "Ann"}}}, shelves)
+}
+
+func TestClient_FollowedShelves_noAccessToken(t *testing.T) {
+	c, done := newRoutedTestClient(t)
+	defer done()
+
+	_, err := c.FollowedShelves(context.Background())
+	assert.Equal(t, ErrNoAccessToken, err)
+}
+
+func friendReview(userID, response string) decodeTestCase {
+	return decodeTestCase{
+		expectURL: fmt.Sprintf("/review/show_by_user_and_book.xml?book_id=50&key=%s&user_id=%s", testAPIKey, userID),
+		response:  response,
+	}
+}
+
+func TestClient_FriendScore(t *testing.T) {
+	c, done := newAuthedTestClient(t,
+		friendsPage(1, `start="1" end="4" total="4"`,
+			`<user><id>2</id></user><user><id>3</id></user><user><id>4</id></user><user><id>5</id></user>`),
+		friendReview("2", `<response><review><id>20</id><rating>5</rating></review></response>`),
+		friendReview("3", `<response><review><id>30</id><rating>4</rating></review></response>`),
+		friendReview("4", `<response><review><id>40</id><rating>0</rating></review></response>`),
+	)
+	defer done()
+
+	score, count, err := c.FriendScore(context.Background(), "1", "50")
+	assert.Nil(t, err)
+	assert.Equal(t, 4.5, score)
+	assert.Equal(t, 2, count)
+}
+
+func TestClient_FriendScore_noRatings(t *testing.T) {
+	c, done := newAuthedTestClient(t,
+		friendsPage(1, `start="1" end="1" total="1"`, `<user><id>2</id></user>`),
+	)
+	defer done()
+
+	score, count, err := c.FriendScore(context.Background(), "1", "50")
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, score)
+	assert.Equal(t, 0, count)
+}
+
+func TestClient_FriendScore_noAccessToken(t *testing.T) {
+	c, done := newRoutedTestClient(t)
+	defer done()
+
+	_, _, err := c.FriendScore(context.Background(), "1", "50")
+	assert.Equal(t, ErrNoAccessToken, err)
+}
@@ -0,0 +1,24 @@
+package goodreads
+
+// genres are the shelf names Goodreads lists as top level genres at
+// https://www.goodreads.com/genres. They match the shelf names returned
+// by BookPopularShelves.
+var genres = []string{
+	"art", "biography", "business", "chick-lit", "childrens", "christian",
+	"classics", "comics", "contemporary", "cookbooks", "crime", "ebooks",
+	"fantasy", "fiction", "gay-and-lesbian", "graphic-novels",
+	"historical-fiction", "history", "horror", "humor-and-comedy", "manga",
+	"memoir", "music", "mystery", "nonfiction", "paranormal", "philosophy",
+	"poetry", "psychology", "religion", "romance", "science",
+	"science-fiction", "self-help", "spirituality", "sports", "suspense",
+	"thriller", "travel", "young-adult",
+}
+
+// Genres returns the canonical list of Goodreads genres, as shelf names.
+//
+// The API has no genre endpoint, so the list is built into the client and
+// no request is made. The error is reserved for a future API backed lookup
+// and is currently always nil.
+func (c *Client) Genres() ([]string, error) {
+	return append([]string(nil), genres...), nil
+}
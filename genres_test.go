@@ -0,0 +1,18 @@
+package goodreads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Genres(t *testing.T) {
+	c := NewClient(testAPIKey)
+	g, err := c.Genres()
+	assert.Nil(t, err)
+	assert.Contains(t, g, "science-fiction")
+
+	g[0] = "modified"
+	g, _ = c.Genres()
+	assert.NotEqual(t, "modified", g[0])
+}
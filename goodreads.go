@@ -4,33 +4,80 @@
 package goodreads
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"github.com/KyleBanks/goodreads/responses"
 	"github.com/KyleBanks/goodreads/responses/work"
+	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// ErrInvalidID is returned, without making a request, when an ID argument
+// is empty or not numeric.
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrNoPageCount is returned when a book's page count is needed but
+// Goodreads does not know it.
+var ErrNoPageCount = errors.New("page count unknown")
+
+// ErrPrivateProfile is returned when a user's shelves are hidden from the
+// API because their profile is private.
+var ErrPrivateProfile = errors.New("profile is private")
+
 // Client wraps the public Goodreads API.
+//
+// A Client is safe for concurrent use by multiple goroutines, provided its
+// exported fields are not modified once it is in use. Internal caches are
+// synchronized.
 type Client struct {
 	APIKey     string
+	apiSecret  string
 	httpClient APIClient
+	timeouts   map[string]time.Duration
+
+	// accessToken and accessSecret, if set, sign every API call on behalf
+	// of the user they were granted by.
+	accessToken, accessSecret string
+
+	cacheBackend Cache
+	memoryCache  MemoryCache
+
+	seriesAPIOrder bool
+	cleanURLs      bool
+	countBatcher   *countBatcher
 }
 
-// NewClient initializes a Client with default parameters.
-func NewClient(key string) *Client {
-	return &Client{
+// NewClient initializes a Client with default parameters, customized by
+// any options provided.
+func NewClient(key string, opts ...Option) *Client {
+	c := &Client{
 		APIKey:     key,
 		httpClient: defaultAPIClient,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // AuthorBooks returns a list of books by a particular author.
 // https://www.goodreads.com/api/index#author.books
 func (c *Client) AuthorBooks(authorID string, page int) (*responses.Author, error) {
+	return c.authorBooks(context.Background(), authorID, page)
+}
+
+func (c *Client) authorBooks(ctx context.Context, authorID string, page int) (*responses.Author, error) {
+	authorID, err := parseID(authorID)
+	if err != nil {
+		return nil, err
+	}
 	v := c.defaultValues()
 	if page > 0 {
 		v.Set("page", strconv.Itoa(page))
@@ -39,7 +86,7 @@ func (c *Client) AuthorBooks(authorID string, page int) (*responses.Author, erro
 	var r struct {
 		Author responses.Author `xml:"author"`
 	}
-	err := c.httpClient.Get(fmt.Sprintf("author/list/%s", authorID), xml.Unmarshal, v, &r)
+	err = c.get(ctx, "AuthorBooks", fmt.Sprintf("author/list/%s", authorID), xml.Unmarshal, v, &r)
 	if err != nil {
 		return nil, err
 	}
@@ -49,34 +96,514 @@ func (c *Client) AuthorBooks(authorID string, page int) (*responses.Author, erro
 // AuthorShow returns the full details of an author.
 // https://www.goodreads.com/api/index#author.show
 func (c *Client) AuthorShow(authorID string) (*responses.Author, error) {
+	return c.authorShow(context.Background(), authorID)
+}
+
+func (c *Client) authorShow(ctx context.Context, authorID string) (*responses.Author, error) {
+	authorID, err := parseID(authorID)
+	if err != nil {
+		return nil, err
+	}
 	var r struct {
 		Author responses.Author `xml:"author"`
 	}
-	err := c.httpClient.Get(fmt.Sprintf("author/show/%s", authorID), xml.Unmarshal, c.defaultValues(), &r)
+	err = c.get(ctx, "AuthorShow", fmt.Sprintf("author/show/%s", authorID), xml.Unmarshal, c.defaultValues(), &r)
 	if err != nil {
 		return nil, err
 	}
 	return &r.Author, nil
 }
 
+// AuthorShowBatch returns the full details of several authors, in the order
+// of authorIDs, fetching a few at a time. If any fail, the error is a
+// *BatchError and their entries are nil, while the others are still
+// returned.
+//
+// With WithCheckpoint, authors completed by an earlier run are not fetched
+// again, and their entries are nil.
+func (c *Client) AuthorShowBatch(ctx context.Context, authorIDs []string, opts ...BatchOption) ([]*responses.Author, error) {
+	cp := newCheckpoint(opts)
+	authors := make([]*responses.Author, len(authorIDs))
+	errs := fetchConcurrently(ctx, len(authorIDs), func(ctx context.Context, i int) error {
+		if cp.completed(authorIDs[i]) {
+			return nil
+		}
+		a, err := c.authorShow(ctx, authorIDs[i])
+		if err != nil {
+			return err
+		}
+		authors[i] = a
+		cp.complete(authorIDs[i])
+		return nil
+	})
+	return authors, newBatchError(authorIDs, errs)
+}
+
 // BookReviewCounts returns the review statistics for a given list of ISBNs.
+// With WithCountBatching, calls made close together share a single request.
 // https://www.goodreads.com/api/index#book.review_counts
 func (c *Client) BookReviewCounts(isbns []string) ([]responses.ReviewCounts, error) {
+	if c.countBatcher != nil && len(isbns) <= maxReviewCountsBatch {
+		return c.countBatcher.counts(isbns)
+	}
+	return c.bookReviewCounts(context.Background(), isbns)
+}
+
+func (c *Client) bookReviewCounts(ctx context.Context, isbns []string) ([]responses.ReviewCounts, error) {
 	v := c.defaultValues()
 	v.Set("isbns", strings.Join(isbns, ","))
 	var r struct {
 		ReviewCounts []responses.ReviewCounts `json:"books"`
 	}
-	err := c.httpClient.Get("book/review_counts.json", json.Unmarshal, v, &r)
+	err := c.get(ctx, "BookReviewCounts", "book/review_counts.json", json.Unmarshal, v, &r)
 	if err != nil {
 		return nil, err
 	}
 	return r.ReviewCounts, nil
 }
 
-// ReviewList returns the books on a members shelf.
+// BookPopularShelves returns the shelf names most used by the community for
+// a book, which double as its genres and tags, most used first.
+func (c *Client) BookPopularShelves(bookID string) ([]responses.PopularShelf, error) {
+	b, err := c.BookShow(bookID)
+	if err != nil {
+		return nil, err
+	}
+	return b.PopularShelves, nil
+}
+
+// BookShow returns the full details of a book.
+// https://www.goodreads.com/api/index#book.show
+func (c *Client) BookShow(bookID string) (*responses.Book, error) {
+	return c.bookShow(context.Background(), bookID)
+}
+
+func (c *Client) bookShow(ctx context.Context, bookID string) (*responses.Book, error) {
+	bookID, err := parseID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		Book responses.Book `xml:"book"`
+	}
+	err = c.get(ctx, "BookShow", fmt.Sprintf("book/show/%s.xml", bookID), xml.Unmarshal, c.defaultValues(), &r)
+	if err != nil {
+		return nil, err
+	}
+	return &r.Book, nil
+}
+
+// BookShowByISBN returns the full details of a book, looked up by ISBN.
+// If Goodreads lists several books for the ISBN, the one with the most
+// ratings is returned; use BooksByISBN to get all of them.
+// https://www.goodreads.com/api/index#book.show_by_isbn
+func (c *Client) BookShowByISBN(isbn string) (*responses.Book, error) {
+	return c.bookShowByISBN(context.Background(), isbn)
+}
+
+func (c *Client) bookShowByISBN(ctx context.Context, isbn string) (*responses.Book, error) {
+	books, err := c.booksByISBN(ctx, "BookShowByISBN", isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	best := &books[0]
+	for i := range books[1:] {
+		if books[i+1].RatingsCount > best.RatingsCount {
+			best = &books[i+1]
+		}
+	}
+	return best, nil
+}
+
+// BooksByISBN returns every book Goodreads lists for an ISBN. Usually this
+// is a single book, but an ISBN shared by several editions returns each of
+// them, in the order given by the API.
+// https://www.goodreads.com/api/index#book.show_by_isbn
+func (c *Client) BooksByISBN(isbn string) ([]responses.Book, error) {
+	return c.booksByISBN(context.Background(), "BooksByISBN", isbn)
+}
+
+func (c *Client) booksByISBN(ctx context.Context, method, isbn string) ([]responses.Book, error) {
+	v := c.defaultValues()
+	v.Set("format", "xml")
+	var r struct {
+		Books []responses.Book `xml:"book"`
+	}
+	err := c.get(ctx, method, fmt.Sprintf("book/isbn/%s", isbn), xml.Unmarshal, v, &r)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Books) == 0 {
+		return nil, ErrNotFound
+	}
+	return r.Books, nil
+}
+
+// wordsPerPage is the typical number of words on the page of a book.
+const wordsPerPage = 250
+
+// CanReview reports whether the authenticated user can write a review of a
+// book, which is when the book exists and they have not reviewed it yet. A
+// review they already have is returned. Books that don't exist return
+// ErrNotFound.
+//
+// The Client must be configured with WithAccessToken.
+func (c *Client) CanReview(ctx context.Context, bookID string) (bool, *responses.Review, error) {
+	userID, err := c.AuthUserID(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	if _, err := c.bookShow(ctx, bookID); err != nil {
+		return false, nil, err
+	}
+
+	r, err := c.reviewShowByUserAndBook(ctx, userID, bookID)
+	if err == ErrNotFound || (err == nil && r.ID == "") {
+		return true, nil, nil
+	} else if err != nil {
+		return false, nil, err
+	}
+	return false, r, nil
+}
+
+// EstimateReadingTime estimates how long a book takes to read at the given
+// reading speed, assuming a typical wordsPerPage words per page.
+// ErrNoPageCount is returned if the page count of the book is unknown.
+func (c *Client) EstimateReadingTime(ctx context.Context, bookID string, wordsPerMinute int) (time.Duration, error) {
+	if wordsPerMinute <= 0 {
+		return 0, fmt.Errorf("words per minute must be positive, got %d", wordsPerMinute)
+	}
+
+	b, err := c.bookShow(ctx, bookID)
+	if err != nil {
+		return 0, err
+	}
+	if b.NumPages <= 0 {
+		return 0, ErrNoPageCount
+	}
+
+	minutes := float64(b.NumPages*wordsPerPage) / float64(wordsPerMinute)
+	return time.Duration(minutes * float64(time.Minute)), nil
+}
+
+// GroupCurrentBook returns the book a group is currently reading, such as a
+// book club's book of the month. ErrNotFound is returned if the group is not
+// currently reading a book.
+// https://www.goodreads.com/api/index#group.show
+func (c *Client) GroupCurrentBook(groupID string) (*responses.Book, error) {
+	groupID, err := parseID(groupID)
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		Books []responses.Book `xml:"group>currently_reading>group_book>book"`
+	}
+	err = c.get(context.Background(), "GroupCurrentBook", fmt.Sprintf("group/show/%s.xml", groupID), xml.Unmarshal, c.defaultValues(), &r)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Books) == 0 {
+		return nil, ErrNotFound
+	}
+	return &r.Books[0], nil
+}
+
+// IDToWorkID returns the work IDs of the given book IDs, in the same order.
+// The work ID of a book that Goodreads does not know is empty.
+//
+// Results never expire from the Client's Cache, since the work an edition
+// belongs to rarely changes.
+// https://www.goodreads.com/api/index#book.id_to_work_id
+func (c *Client) IDToWorkID(bookIDs []string) ([]string, error) {
+	return c.idToWorkID(context.Background(), bookIDs)
+}
+
+// maxIDToWorkIDBatch bounds the number of book IDs sent per request.
+const maxIDToWorkIDBatch = 200
+
+func (c *Client) idToWorkID(ctx context.Context, bookIDs []string) ([]string, error) {
+	bookIDs = append([]string(nil), bookIDs...)
+	for i, id := range bookIDs {
+		var err error
+		if bookIDs[i], err = parseID(id); err != nil {
+			return nil, err
+		}
+	}
+
+	workIDs := make([]string, len(bookIDs))
+	var missing []int
+	for i, id := range bookIDs {
+		if w, ok := c.cache().Get(workIDCacheKey(id)); ok {
+			workIDs[i] = string(w)
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > maxIDToWorkIDBatch {
+			batch = batch[:maxIDToWorkIDBatch]
+		}
+		missing = missing[len(batch):]
+
+		ids := make([]string, len(batch))
+		for j, i := range batch {
+			ids[j] = bookIDs[i]
+		}
+
+		var r struct {
+			Items []string `xml:"work-ids>item"`
+		}
+		err := c.get(ctx, "IDToWorkID", fmt.Sprintf("book/id_to_work_id/%s", strings.Join(ids, ",")), xml.Unmarshal, c.defaultValues(), &r)
+		if err != nil {
+			return nil, err
+		}
+		if len(r.Items) != len(ids) {
+			return nil, fmt.Errorf("expected %d work IDs, got %d", len(ids), len(r.Items))
+		}
+
+		for j, i := range batch {
+			w := strings.TrimSpace(r.Items[j])
+			workIDs[i] = w
+			if w != "" {
+				c.cache().Set(workIDCacheKey(bookIDs[i]), []byte(w), 0)
+			}
+		}
+	}
+	return workIDs, nil
+}
+
+// ISBNExists reports whether Goodreads knows a book with the given ISBN,
+// using the lightweight isbn_to_id method. Malformed ISBNs are reported as
+// not existing without making a request.
+func (c *Client) ISBNExists(ctx context.Context, isbn string) (bool, error) {
+	isbn, ok := normalizeISBN(isbn)
+	if !ok {
+		return false, nil
+	}
+
+	_, err := c.isbnToID(ctx, isbn)
+	if err == ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ISBNToID returns the Goodreads book ID of an ISBN.
+// https://www.goodreads.com/api/index#book.isbn_to_id
+func (c *Client) ISBNToID(isbn string) (string, error) {
+	return c.isbnToID(context.Background(), isbn)
+}
+
+func (c *Client) isbnToID(ctx context.Context, isbn string) (string, error) {
+	var id string
+	err := c.get(ctx, "ISBNToID", fmt.Sprintf("book/isbn_to_id/%s", isbn), decodeText, c.defaultValues(), &id)
+	if err != nil {
+		return "", err
+	}
+	if id = strings.TrimSpace(id); id == "" {
+		return "", ErrNotFound
+	}
+	return id, nil
+}
+
+// MoreByAuthor returns up to limit of the most rated books by the primary
+// author of a book, leaving out the book itself. The primary author is the
+// first one credited without a role such as "Illustrator".
+//
+// Only the first page of the author's books is considered. The author of
+// each book is kept in the Client's Cache, so repeat calls for a book only
+// fetch the author's books.
+func (c *Client) MoreByAuthor(ctx context.Context, bookID string, limit int) ([]responses.AuthorBook, error) {
+	bookID, err := parseID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	authorID, err := c.primaryAuthorID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := c.authorBooks(ctx, authorID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]responses.AuthorBook, 0, len(a.Books))
+	for _, b := range a.Books {
+		if b.ID != bookID {
+			books = append(books, b)
+		}
+	}
+	sort.SliceStable(books, func(i, j int) bool {
+		return books[i].RatingsCount > books[j].RatingsCount
+	})
+	if len(books) > limit {
+		books = books[:limit]
+	}
+	return books, nil
+}
+
+// primaryAuthorID returns the ID of the primary author of a book, as
+// described by MoreByAuthor.
+func (c *Client) primaryAuthorID(ctx context.Context, bookID string) (string, error) {
+	key := "primary_author_id:" + bookID
+	if id, ok := c.cache().Get(key); ok {
+		return string(id), nil
+	}
+
+	b, err := c.bookShow(ctx, bookID)
+	if err != nil {
+		return "", err
+	}
+	if len(b.Authors) == 0 {
+		return "", ErrNotFound
+	}
+	id := b.Authors[0].ID
+	for _, a := range b.Authors {
+		if strings.TrimSpace(a.Role) == "" {
+			id = a.ID
+			break
+		}
+	}
+	c.cache().Set(key, []byte(id), 0)
+	return id, nil
+}
+
+// NewReleases returns the works matching a genre, ordered from the most
+// recently published to the oldest.
+//
+// Goodreads has no dedicated new releases endpoint, so this searches all
+// fields for the genre and sorts the requested page of results by original
+// publication date. Works with an unknown publication year are placed last.
+func (c *Client) NewReleases(genre string, page int) ([]work.Work, error) {
+	works, err := c.SearchBooks(genre, page, AllFields)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(works, func(i, j int) bool {
+		a, b := works[i], works[j]
+		if a.OriginalPublicationYear != b.OriginalPublicationYear {
+			if a.OriginalPublicationYear == 0 || b.OriginalPublicationYear == 0 {
+				return b.OriginalPublicationYear == 0
+			}
+			return a.OriginalPublicationYear > b.OriginalPublicationYear
+		}
+		if a.OriginalPublicationMonth != b.OriginalPublicationMonth {
+			return a.OriginalPublicationMonth > b.OriginalPublicationMonth
+		}
+		return a.OriginalPublicationDay > b.OriginalPublicationDay
+	})
+	return works, nil
+}
+
+// PopularAuthors returns the authors of the works matching a genre, ranked
+// from the most fans to the fewest, with followers breaking ties.
+//
+// Goodreads has no author popularity endpoint, so this searches all fields
+// for the genre, as NewReleases does, and fetches the full details of each
+// author on the requested page of results. Authors Goodreads no longer
+// knows are left out.
+func (c *Client) PopularAuthors(genre string, page int) ([]responses.Author, error) {
+	works, err := c.SearchBooks(genre, page, AllFields)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	seen := make(map[int]bool)
+	for _, w := range works {
+		id := w.BestBook.Author.ID
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, strconv.Itoa(id))
+	}
+
+	fetched, err := c.AuthorShowBatch(context.Background(), ids)
+	if be, ok := err.(*BatchError); ok {
+		for _, err := range be.Errors {
+			if err != ErrNotFound {
+				return nil, be
+			}
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	authors := make([]responses.Author, 0, len(fetched))
+	for _, a := range fetched {
+		if a != nil {
+			authors = append(authors, *a)
+		}
+	}
+	sort.SliceStable(authors, func(i, j int) bool {
+		if authors[i].FansCount != authors[j].FansCount {
+			return authors[i].FansCount > authors[j].FansCount
+		}
+		return authors[i].AuthorFollowers > authors[j].AuthorFollowers
+	})
+	return authors, nil
+}
+
+// ResolveBook returns the full details of the book best matching free-form
+// user input.
+//
+// The query is tried, in order, as an ISBN if it is a well-formed one or
+// otherwise as a Goodreads book ID if it is numeric, and finally as a
+// search, in which case the best book of the top result is returned. ISBNs
+// are never looked up as IDs, as Goodreads would read an ISBN-10 such as
+// 0060929871 as the ID of an unrelated book. A lookup that comes back
+// ErrNotFound falls through to the search; any other error is returned
+// immediately. ErrNotFound is returned when no strategy matches.
+func (c *Client) ResolveBook(ctx context.Context, query string) (*responses.Book, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrNotFound
+	}
+
+	if isbn, ok := normalizeISBN(query); ok {
+		b, err := c.bookShowByISBN(ctx, isbn)
+		if err != ErrNotFound {
+			return b, err
+		}
+	} else if isNumeric(query) {
+		b, err := c.bookShow(ctx, query)
+		if err != ErrNotFound {
+			return b, err
+		}
+	}
+
+	works, err := c.searchBooks(ctx, query, 1, AllFields)
+	if err != nil {
+		return nil, err
+	}
+	if len(works) == 0 {
+		return nil, ErrNotFound
+	}
+	return c.bookShow(ctx, strconv.Itoa(works[0].BestBook.ID))
+}
+
+// ReviewList returns the books on a members shelf. ErrPrivateProfile is
+// returned if the member's profile is private.
 // https://www.goodreads.com/api/index#reviews.list
 func (c *Client) ReviewList(userID, shelf, sort, search, order string, page, perPage int) ([]responses.Review, error) {
+	reviews, _, err := c.reviewList(context.Background(), userID, shelf, sort, search, order, page, perPage)
+	return reviews, err
+}
+
+func (c *Client) reviewList(ctx context.Context, userID, shelf, sort, search, order string, page, perPage int) ([]responses.Review, responses.Pagination, error) {
+	userID, err := parseID(userID)
+	if err != nil {
+		return nil, responses.Pagination{}, err
+	}
 	v := c.defaultValues()
 	v.Set("v", "2")
 	if shelf != "" {
@@ -99,19 +626,114 @@ func (c *Client) ReviewList(userID, shelf, sort, search, order string, page, per
 	}
 
 	var r struct {
-		Reviews []responses.Review `xml:"reviews>review"`
+		Reviews struct {
+			responses.Pagination
+			Reviews []responses.Review `xml:"review"`
+		} `xml:"reviews"`
+	}
+	err = c.get(ctx, "ReviewList", fmt.Sprintf("review/list/%s.xml", userID), xml.Unmarshal, v, &r)
+	var se *StatusError
+	if errors.As(err, &se) && (se.Code == http.StatusUnauthorized || se.Code == http.StatusForbidden) {
+		return nil, responses.Pagination{}, ErrPrivateProfile
+	} else if err != nil {
+		return nil, responses.Pagination{}, err
+	}
+	return r.Reviews.Reviews, r.Reviews.Pagination, nil
+}
+
+// ReviewShow returns a single review, along with the book it is of.
+// https://www.goodreads.com/api/index#review.show
+func (c *Client) ReviewShow(reviewID string) (*responses.Review, error) {
+	return c.reviewShow(context.Background(), reviewID)
+}
+
+func (c *Client) reviewShow(ctx context.Context, reviewID string) (*responses.Review, error) {
+	_, r, err := c.reviewShowRaw(ctx, reviewID)
+	return r, err
+}
+
+// ReviewShowRaw is like ReviewShow, but also returns the body of the response
+// as Goodreads sent it, for callers that need fields the parsed Review
+// doesn't have.
+func (c *Client) ReviewShowRaw(ctx context.Context, reviewID string) ([]byte, *responses.Review, error) {
+	return c.reviewShowRaw(ctx, reviewID)
+}
+
+func (c *Client) reviewShowRaw(ctx context.Context, reviewID string) ([]byte, *responses.Review, error) {
+	reviewID, err := parseID(reviewID)
+	if err != nil {
+		return nil, nil, err
+	}
+	v := c.defaultValues()
+	v.Set("id", reviewID)
+	var r struct {
+		Review responses.Review `xml:"review"`
 	}
-	err := c.httpClient.Get(fmt.Sprintf("review/list/%s.xml", userID), xml.Unmarshal, v, &r)
+	raw, err := c.getDecoded(ctx, "ReviewShow", "review/show.xml", xml.Unmarshal, v, &r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, &r.Review, nil
+}
+
+// ReviewShowByUserAndBook returns a user's review of a particular book.
+// https://www.goodreads.com/api/index#review.show_by_user_and_book
+func (c *Client) ReviewShowByUserAndBook(userID, bookID string) (*responses.Review, error) {
+	return c.reviewShowByUserAndBook(context.Background(), userID, bookID)
+}
+
+func (c *Client) reviewShowByUserAndBook(ctx context.Context, userID, bookID string) (*responses.Review, error) {
+	userID, err := parseID(userID)
 	if err != nil {
 		return nil, err
 	}
-	return r.Reviews, nil
+	bookID, err = parseID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	v := c.defaultValues()
+	v.Set("user_id", userID)
+	v.Set("book_id", bookID)
+	var r struct {
+		Review responses.Review `xml:"review"`
+	}
+	err = c.get(ctx, "ReviewShowByUserAndBook", "review/show_by_user_and_book.xml", xml.Unmarshal, v, &r)
+	if err != nil {
+		return nil, err
+	}
+	return &r.Review, nil
+}
+
+// SameWork reports whether two books are editions of the same work. The
+// lookups share the work ID cache of IDToWorkID, and errors name the book
+// that could not be resolved.
+func (c *Client) SameWork(ctx context.Context, bookIDA, bookIDB string) (bool, error) {
+	for _, id := range []string{bookIDA, bookIDB} {
+		if _, err := parseID(id); err != nil {
+			return false, fmt.Errorf("book %q: %w", id, err)
+		}
+	}
+
+	workIDs, err := c.idToWorkID(ctx, []string{bookIDA, bookIDB})
+	if err != nil {
+		return false, err
+	}
+	for i, id := range []string{bookIDA, bookIDB} {
+		if workIDs[i] == "" {
+			return false, fmt.Errorf("book %q: %w", id, ErrNotFound)
+		}
+	}
+	return workIDs[0] == workIDs[1], nil
 }
 
 // SearchBooks returns a list of books based on a query string
 // by title, author, or ISBN.
 // https://www.goodreads.com/api/index#search.books
 func (c *Client) SearchBooks(query string, page int, field SearchField) ([]work.Work, error) {
+	return c.searchBooks(context.Background(), query, page, field)
+}
+
+func (c *Client) searchBooks(ctx context.Context, query string, page int, field SearchField) ([]work.Work, error) {
 	v := c.defaultValues()
 	v.Set("q", query)
 	v.Set("search[field]", string(field))
@@ -123,7 +745,7 @@ func (c *Client) SearchBooks(query string, page int, field SearchField) ([]work.
 		Works []work.Work `xml:"search>results>work"`
 	}
 
-	err := c.httpClient.Get("search/index.xml", xml.Unmarshal, v, &r)
+	err := c.get(ctx, "SearchBooks", "search/index.xml", xml.Unmarshal, v, &r)
 	if err != nil {
 		return nil, err
 	}
@@ -131,15 +753,163 @@ func (c *Client) SearchBooks(query string, page int, field SearchField) ([]work.
 	return r.Works, nil
 }
 
+// SeriesNeighbors returns the books before and after a book in its series,
+// by position, for "previous" and "next" links. prev is nil for the first
+// book and next is nil for the last.
+//
+// When the book belongs to several series the first one listed is used.
+// Entries without a numeric position are skipped, and ErrNotFound is
+// returned if the book is not part of a series or has no numeric position.
+func (c *Client) SeriesNeighbors(ctx context.Context, bookID string) (prev, next *responses.Book, err error) {
+	b, err := c.bookShow(ctx, bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(b.SeriesWorks) == 0 || b.SeriesWorks[0].Series == nil {
+		return nil, nil, ErrNotFound
+	}
+	pos, ok := seriesPosition(b.SeriesWorks[0].UserPosition)
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+
+	s, err := c.seriesShow(ctx, b.SeriesWorks[0].Series.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var before, after *responses.SeriesWork
+	var beforePos, afterPos float64
+	for i, sw := range s.SeriesWorks {
+		p, ok := seriesPosition(sw.UserPosition)
+		if !ok {
+			continue
+		}
+		if p < pos && (before == nil || p > beforePos) {
+			before, beforePos = &s.SeriesWorks[i], p
+		}
+		if p > pos && (after == nil || p < afterPos) {
+			after, afterPos = &s.SeriesWorks[i], p
+		}
+	}
+
+	if before != nil {
+		if prev, err = c.bookShow(ctx, strconv.Itoa(before.Work.BestBook.ID)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if after != nil {
+		if next, err = c.bookShow(ctx, strconv.Itoa(after.Work.BestBook.ID)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return prev, next, nil
+}
+
+// SeriesShow returns the details of a series, including its works.
+//
+// Works are sorted by their numeric position in the series, so that a
+// novella at position 3.5 follows book 3. Works without a numeric position
+// come last, in the order returned by the API. The WithSeriesAPIOrder
+// option preserves the API's order instead.
+// https://www.goodreads.com/api/index#series.show
+func (c *Client) SeriesShow(seriesID string) (*responses.Series, error) {
+	return c.seriesShow(context.Background(), seriesID)
+}
+
+func (c *Client) seriesShow(ctx context.Context, seriesID string) (*responses.Series, error) {
+	seriesID, err := parseID(seriesID)
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		Series responses.Series `xml:"series"`
+	}
+	err = c.get(ctx, "SeriesShow", fmt.Sprintf("series/show/%s.xml", seriesID), xml.Unmarshal, c.defaultValues(), &r)
+	if err != nil {
+		return nil, err
+	}
+	if !c.seriesAPIOrder {
+		sortSeriesWorks(r.Series.SeriesWorks)
+	}
+	return &r.Series, nil
+}
+
+// SeriesStart returns the first book of the series that a book belongs to,
+// for "start here" recommendations.
+//
+// When the book belongs to several series the first one listed is used.
+// The entry at position 1 is preferred; if the series has no such entry the
+// lowest numbered position is returned instead. ErrNotFound is returned if
+// the book is not part of a series.
+func (c *Client) SeriesStart(ctx context.Context, bookID string) (*responses.Book, error) {
+	b, err := c.bookShow(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.SeriesWorks) == 0 || b.SeriesWorks[0].Series == nil {
+		return nil, ErrNotFound
+	}
+
+	s, err := c.seriesShow(ctx, b.SeriesWorks[0].Series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var first *responses.SeriesWork
+	var firstPos float64
+	for i, sw := range s.SeriesWorks {
+		pos, ok := seriesPosition(sw.UserPosition)
+		if !ok {
+			continue
+		}
+		if first == nil || pos == 1 || (firstPos != 1 && pos < firstPos) {
+			first, firstPos = &s.SeriesWorks[i], pos
+		}
+	}
+	if first == nil {
+		return nil, ErrNotFound
+	}
+	return c.bookShow(ctx, strconv.Itoa(first.Work.BestBook.ID))
+}
+
+// ShelfTagCloud returns the number of books on each of a user's shelves,
+// keyed by shelf name, leaving out the exclusive shelves such as "read" and
+// "to-read" so that only the user's own tags remain.
+func (c *Client) ShelfTagCloud(userID string) (map[string]int, error) {
+	shelves, err := c.ShelvesList(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cloud := make(map[string]int)
+	for _, s := range shelves {
+		if s.ExclusiveFlag {
+			continue
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(s.BookCount))
+		cloud[s.Name] = n
+	}
+	return cloud, nil
+}
+
 // ShelvesList returns the list of shelves belonging to a user.
 // https://www.goodreads.com/api/index#shelves.list
 func (c *Client) ShelvesList(userID string) ([]responses.UserShelf, error) {
+	return c.shelvesList(context.Background(), userID)
+}
+
+func (c *Client) shelvesList(ctx context.Context, userID string) ([]responses.UserShelf, error) {
+	userID, err := parseID(userID)
+	if err != nil {
+		return nil, err
+	}
 	v := c.defaultValues()
 	v.Set("user_id", userID)
 	var r struct {
 		Shelves []responses.UserShelf `xml:"shelves>user_shelf"`
 	}
-	err := c.httpClient.Get("shelf/list.xml", xml.Unmarshal, v, &r)
+	err = c.get(ctx, "ShelvesList", "shelf/list.xml", xml.Unmarshal, v, &r)
 	if err != nil {
 		return nil, err
 	}
@@ -149,18 +919,204 @@ func (c *Client) ShelvesList(userID string) ([]responses.UserShelf, error) {
 // UserShow returns the public information about a given Goodreads user.
 // https://www.goodreads.com/api/index#user.show
 func (c *Client) UserShow(id string) (*responses.User, error) {
+	id, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
 	var r struct {
 		User responses.User `xml:"user"`
 	}
-	err := c.httpClient.Get(fmt.Sprintf("user/show/%s.xml", id), xml.Unmarshal, c.defaultValues(), &r)
+	err = c.get(context.Background(), "UserShow", fmt.Sprintf("user/show/%s.xml", id), xml.Unmarshal, c.defaultValues(), &r)
 	if err != nil {
 		return nil, err
 	}
 	return &r.User, nil
 }
 
+// WorkEditions returns a page of the editions of a work. Goodreads only
+// serves this method to API keys that have been granted access to it.
+// https://www.goodreads.com/api/index#work.editions
+func (c *Client) WorkEditions(workID string, page int) ([]responses.Book, error) {
+	editions, _, err := c.workEditions(context.Background(), workID, page)
+	return editions, err
+}
+
+func (c *Client) workEditions(ctx context.Context, workID string, page int) ([]responses.Book, responses.Pagination, error) {
+	workID, err := parseID(workID)
+	if err != nil {
+		return nil, responses.Pagination{}, err
+	}
+	v := c.defaultValues()
+	v.Set("format", "xml")
+	if page > 0 {
+		v.Set("page", strconv.Itoa(page))
+	}
+	var r struct {
+		Editions struct {
+			responses.Pagination
+			Books []responses.Book `xml:"book"`
+		} `xml:"editions"`
+	}
+	err = c.get(ctx, "WorkEditions", fmt.Sprintf("work/editions/%s", workID), xml.Unmarshal, v, &r)
+	if err != nil {
+		return nil, responses.Pagination{}, err
+	}
+	return r.Editions.Books, r.Editions.Pagination, nil
+}
+
+// get performs a request on behalf of the named Client method, bounded by
+// the timeout configured for that method, if any.
+func (c *Client) get(ctx context.Context, method, endpoint string, decoder func([]byte, interface{}) error, v url.Values, out interface{}) error {
+	if d, ok := c.timeouts[method]; ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+	if err := c.httpClient.Get(ctx, endpoint, decoder, v, out); err != nil {
+		return err
+	}
+	if c.cleanURLs {
+		cleanURLs(out)
+	}
+	return nil
+}
+
+// getDecoded is like get, but also returns the body of the response as it
+// was before decoding. The decoder is picked from the Content-Type of the
+// response as Get does, and with an APIClient that can't return the body
+// as is, the body the APIClient hands its decoder is returned.
+func (c *Client) getDecoded(ctx context.Context, method, endpoint string, decoder func([]byte, interface{}) error, v url.Values, out interface{}) ([]byte, error) {
+	if d, ok := c.timeouts[method]; ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	var raw []byte
+	if g, ok := c.httpClient.(rawGetter); ok {
+		body, contentType, err := g.GetRaw(ctx, endpoint, v)
+		if err != nil {
+			return nil, err
+		}
+		if err := contentTypeDecoder(contentType, decoder)(body, out); err != nil {
+			return nil, err
+		}
+		raw = body
+	} else {
+		keep := func(b []byte, out interface{}) error {
+			raw = append([]byte(nil), b...)
+			return decoder(b, out)
+		}
+		if err := c.httpClient.Get(ctx, endpoint, keep, v, out); err != nil {
+			return nil, err
+		}
+	}
+	if c.cleanURLs {
+		cleanURLs(out)
+	}
+	return raw, nil
+}
+
 func (c *Client) defaultValues() url.Values {
 	v := url.Values{}
 	v.Set("key", c.APIKey)
 	return v
 }
+
+// parseID strips the slug from an ID such as "18541.Tim_O_Reilly" or
+// "38763538-kyle", and checks that the remaining ID is numeric.
+func parseID(id string) (string, error) {
+	stripped := strings.TrimSpace(id)
+	if i := strings.IndexAny(stripped, ".-"); i >= 0 {
+		stripped = stripped[:i]
+	}
+	if !isNumeric(stripped) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return stripped, nil
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeText decodes a plain text response into a *string.
+func decodeText(b []byte, v interface{}) error {
+	*v.(*string) = string(b)
+	return nil
+}
+
+// compactISBN removes the hyphens and spaces from an ISBN and uppercases a
+// trailing x, without checking that the result is well-formed.
+func compactISBN(isbn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+}
+
+// normalizeISBN removes the hyphens and spaces from an ISBN-10 or ISBN-13,
+// and reports whether the result is a well-formed ISBN with a valid check
+// digit.
+func normalizeISBN(isbn string) (string, bool) {
+	isbn = compactISBN(isbn)
+
+	switch len(isbn) {
+	case 10:
+		if !isNumeric(isbn[:9]) || !(isNumeric(isbn[9:]) || isbn[9] == 'X') {
+			return "", false
+		}
+		sum := 0
+		for i, r := range isbn {
+			d := int(r - '0')
+			if r == 'X' {
+				d = 10
+			}
+			sum += (10 - i) * d
+		}
+		if sum%11 != 0 {
+			return "", false
+		}
+		return isbn, true
+	case 13:
+		if !isNumeric(isbn) {
+			return "", false
+		}
+		sum := 0
+		for i, r := range isbn {
+			d := int(r - '0')
+			if i%2 == 1 {
+				d *= 3
+			}
+			sum += d
+		}
+		if sum%10 != 0 {
+			return "", false
+		}
+		return isbn, true
+	}
+	return "", false
+}
+
+// seriesPosition parses the position of a work within a series, which may be
+// fractional. Positions such as "1-3" or "" are not numeric.
+func seriesPosition(s string) (float64, bool) {
+	pos, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return pos, err == nil
+}
+
+func sortSeriesWorks(works []responses.SeriesWork) {
+	sort.SliceStable(works, func(i, j int) bool {
+		a, aok := seriesPosition(works[i].UserPosition)
+		b, bok := seriesPosition(works[j].UserPosition)
+		if aok != bok {
+			return aok
+		}
+		return aok && a < b
+	})
+}
@@ -1,12 +1,18 @@
 package goodreads
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/KyleBanks/goodreads/responses"
 	"github.com/KyleBanks/goodreads/responses/work"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -50,6 +56,40 @@ func TestClient_AuthorShow(t *testing.T) {
 	}, *a)
 }
 
+func TestClient_AuthorShowBatch(t *testing.T) {
+	var tcs []decodeTestCase
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		tcs = append(tcs, decodeTestCase{
+			expectURL: fmt.Sprintf("/author/show/%s?key=%s", id, testAPIKey),
+			response:  fmt.Sprintf(`<response><author><id>%s</id></author></response>`, id),
+		})
+	}
+	c, done := newRoutedTestClient(t, tcs...)
+	defer done()
+
+	authors, err := c.AuthorShowBatch(context.Background(), []string{"5", "4", "3", "2", "1"})
+	assert.Nil(t, err)
+	var ids []string
+	for _, a := range authors {
+		ids = append(ids, a.ID)
+	}
+	assert.Equal(t, []string{"5", "4", "3", "2", "1"}, ids)
+
+	authors, err = c.AuthorShowBatch(context.Background(), []string{"1", "99", "bad", "2"})
+	assert.Equal(t, "1", authors[0].ID)
+	assert.Nil(t, authors[1])
+	assert.Nil(t, authors[2])
+	assert.Equal(t, "2", authors[3].ID)
+
+	be, ok := err.(*BatchError)
+	if assert.True(t, ok) {
+		assert.Len(t, be.Errors, 2)
+		assert.Equal(t, ErrNotFound, be.Errors["99"])
+		assert.True(t, errors.Is(be.Errors["bad"], ErrInvalidID))
+		assert.True(t, strings.HasPrefix(err.Error(), "2 of the batch failed: 99: "))
+	}
+}
+
 func TestClient_BookReviewCounts(t *testing.T) {
 	isbn := "9781400078776"
 	c, done := newTestClient(t, decodeTestCase{
@@ -89,9 +129,435 @@ func TestClient_BookReviewCounts(t *testing.T) {
 	}, counts)
 }
 
+func TestClient_BookPopularShelves(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/book/show/50.xml?key=%s", testAPIKey),
+		response: `<response>
+			<book>
+				<id>50</id>
+				<popular_shelves>
+					<shelf name="to-read" count="31645"/>
+					<shelf name="young-adult" count="1959"/>
+				</popular_shelves>
+			</book>
+		</response>`,
+	})
+	defer done()
+
+	s, err := c.BookPopularShelves("50")
+	assert.Nil(t, err)
+	assert.Equal(t, []responses.PopularShelf{
+		{Name: "to-read", Count: 31645},
+		{Name: "young-adult", Count: 1959},
+	}, s)
+}
+
+func TestClient_BookShow(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/book/show/50.xml?key=%s", testAPIKey),
+		response: `<response>
+			<book>
+				<id>50</id>
+				<title>Hatchet</title>
+				<num_pages>208</num_pages>
+				<authors><author><id>18</id><name>Gary Paulsen</name></author></authors>
+			</book>
+		</response>`,
+	})
+	defer done()
+
+	b, err := c.BookShow("50")
+	assert.Nil(t, err)
+	assert.Equal(t, responses.Book{
+		ID:       "50",
+		Title:    "Hatchet",
+		NumPages: 208,
+		Authors:  []responses.Author{{ID: "18", Name: "Gary Paulsen"}},
+	}, *b)
+}
+
+func TestClient_BookShowByISBN(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/book/isbn/0441172717?format=xml&key=%s", testAPIKey),
+		response:  `<response><book><id>234225</id><isbn>0441172717</isbn><title>Dune</title></book></response>`,
+	})
+	defer done()
+
+	b, err := c.BookShowByISBN("0441172717")
+	assert.Nil(t, err)
+	assert.Equal(t, responses.Book{
+		ID:    "234225",
+		ISBN:  "0441172717",
+		Title: "Dune",
+	}, *b)
+}
+
+func TestClient_EstimateReadingTime(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/50.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>50</id><num_pages>208</num_pages></book></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/51.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>51</id><num_pages></num_pages></book></response>`,
+		},
+	)
+	defer done()
+
+	d, err := c.EstimateReadingTime(context.Background(), "50", 250)
+	assert.Nil(t, err)
+	assert.Equal(t, 208*time.Minute, d)
+
+	_, err = c.EstimateReadingTime(context.Background(), "51", 250)
+	assert.Equal(t, ErrNoPageCount, err)
+
+	_, err = c.EstimateReadingTime(context.Background(), "50", 0)
+	assert.NotNil(t, err)
+}
+
+func TestClient_GroupCurrentBook(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/group/show/1.xml?key=%s", testAPIKey),
+			response: `<GoodreadsResponse><group>
+				<id>1</id>
+				<title>Goodreads Librarians Group</title>
+				<currently_reading>
+					<group_book>
+						<id>303</id>
+						<start_on>2019-06-01</start_on>
+						<book><id>50</id><title>Hatchet</title></book>
+					</group_book>
+				</currently_reading>
+			</group></GoodreadsResponse>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/group/show/2.xml?key=%s", testAPIKey),
+			response:  `<GoodreadsResponse><group><id>2</id><currently_reading></currently_reading></group></GoodreadsResponse>`,
+		},
+	)
+	defer done()
+
+	b, err := c.GroupCurrentBook("1-goodreads-librarians-group")
+	assert.Nil(t, err)
+	assert.Equal(t, "50", b.ID)
+	assert.Equal(t, "Hatchet", b.Title)
+
+	_, err = c.GroupCurrentBook("2")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestClient_IDToWorkID(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/book/id_to_work_id/50,1,2?key=%s", testAPIKey),
+		response: `<GoodreadsResponse>
+			<work-ids><item>1158125</item><item>41335427</item><item></item></work-ids>
+		</GoodreadsResponse>`,
+	})
+	defer done()
+
+	w, err := c.IDToWorkID([]string{"50", "1", "2"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1158125", "41335427", ""}, w)
+
+	// Cached IDs are not requested again.
+	done()
+	w, err = c.IDToWorkID([]string{"1", "50"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"41335427", "1158125"}, w)
+}
+
+func TestClient_BooksByISBN(t *testing.T) {
+	response := `<response>
+		<book><id>1</id><title>Edition A</title><ratings_count>10</ratings_count></book>
+		<book><id>2</id><title>Edition B</title><ratings_count>30</ratings_count></book>
+		<book><id>3</id><title>Edition C</title><ratings_count>20</ratings_count></book>
+	</response>`
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/book/isbn/9780000000002?format=xml&key=%s", testAPIKey),
+		response:  response,
+	})
+	defer done()
+
+	books, err := c.BooksByISBN("9780000000002")
+	assert.Nil(t, err)
+	assert.Len(t, books, 3)
+	assert.Equal(t, "1", books[0].ID)
+
+	b, err := c.BookShowByISBN("9780000000002")
+	assert.Nil(t, err)
+	assert.Equal(t, "2", b.ID)
+}
+
+func TestClient_ISBNToID(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/book/isbn_to_id/0441172717?key=%s", testAPIKey),
+		response:  "234225",
+	})
+	defer done()
+
+	id, err := c.ISBNToID("0441172717")
+	assert.Nil(t, err)
+	assert.Equal(t, "234225", id)
+}
+
+func TestClient_ISBNExists(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/book/isbn_to_id/0441172717?key=%s", testAPIKey),
+		response:  "234225",
+	})
+	defer done()
+
+	testCases := []struct {
+		isbn   string
+		exists bool
+	}{
+		{"0-441-17271-7", true},
+		{"9780441172719", false},
+		{"0441172718", false},
+		{"not an isbn", false},
+	}
+	for _, tc := range testCases {
+		exists, err := c.ISBNExists(context.Background(), tc.isbn)
+		assert.Nil(t, err, tc.isbn)
+		assert.Equal(t, tc.exists, exists, tc.isbn)
+	}
+}
+
+func TestNormalizeISBN(t *testing.T) {
+	testCases := []struct {
+		in     string
+		expect string
+		ok     bool
+	}{
+		{"0-441-17271-7", "0441172717", true},
+		{"978-0-441-17271-9", "9780441172719", true},
+		{"0-8044-2957-x", "080442957X", true},
+		{"0441172718", "", false},
+		{"9780441172710", "", false},
+		{"12345", "", false},
+		{"X441172717", "", false},
+	}
+	for _, tc := range testCases {
+		isbn, ok := normalizeISBN(tc.in)
+		assert.Equal(t, tc.expect, isbn, tc.in)
+		assert.Equal(t, tc.ok, ok, tc.in)
+	}
+}
+
+func TestClient_MoreByAuthor(t *testing.T) {
+	var bookShows int
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/50.xml?key=%s", testAPIKey),
+			response: `<response><book><id>50</id><authors>
+				<author><id>99</id><role>Illustrator</role></author>
+				<author><id>18</id><role></role></author>
+			</authors></book></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/author/list/18?key=%s&page=1", testAPIKey),
+			response: `<response><author><id>18</id><books>
+				<book><id>50</id><ratings_count>1000</ratings_count></book>
+				<book><id>51</id><ratings_count>10</ratings_count></book>
+				<book><id>52</id><ratings_count>300</ratings_count></book>
+				<book><id>53</id><ratings_count>20</ratings_count></book>
+			</books></author></response>`,
+		},
+	)
+	defer done()
+	c.httpClient = countingAPIClient{c.httpClient, "book/show/", &bookShows}
+
+	books, err := c.MoreByAuthor(context.Background(), "50.Hatchet", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []responses.AuthorBook{{ID: "52", RatingsCount: 300}, {ID: "53", RatingsCount: 20}}, books)
+
+	books, err = c.MoreByAuthor(context.Background(), "50", 10)
+	assert.Nil(t, err)
+	assert.Len(t, books, 3)
+	assert.Equal(t, 1, bookShows)
+}
+
+// countingAPIClient counts the requests made to endpoints with a prefix.
+type countingAPIClient struct {
+	APIClient
+	prefix string
+	n      *int
+}
+
+func (c countingAPIClient) Get(ctx context.Context, endpoint string, decoder func([]byte, interface{}) error, v url.Values, out interface{}) error {
+	if strings.HasPrefix(endpoint, c.prefix) {
+		*c.n++
+	}
+	return c.APIClient.Get(ctx, endpoint, decoder, v, out)
+}
+
+func TestClient_NewReleases(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/search/index.xml?key=%s&page=2&q=fantasy&search%%5Bfield%%5D=all", testAPIKey),
+		response: `<response>
+			<search>
+				<results>
+					<work><id>1</id><original_publication_year>2017</original_publication_year></work>
+					<work><id>2</id></work>
+					<work><id>3</id><original_publication_year>2019</original_publication_year><original_publication_month>2</original_publication_month></work>
+					<work><id>4</id><original_publication_year>2019</original_publication_year><original_publication_month>8</original_publication_month></work>
+				</results>
+			</search>
+		</response>`,
+	})
+	defer done()
+
+	works, err := c.NewReleases("fantasy", 2)
+	assert.Nil(t, err)
+	var ids []int
+	for _, w := range works {
+		ids = append(ids, w.ID)
+	}
+	assert.Equal(t, []int{4, 3, 1, 2}, ids)
+}
+
+func TestClient_PopularAuthors(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/search/index.xml?key=%s&page=1&q=fantasy&search%%5Bfield%%5D=all", testAPIKey),
+			response: `<response><search><results>
+				<work><best_book><author><id>1</id></author></best_book></work>
+				<work><best_book><author><id>2</id></author></best_book></work>
+				<work><best_book><author><id>1</id></author></best_book></work>
+				<work><best_book><author><id>3</id></author></best_book></work>
+				<work><best_book><author><id>4</id></author></best_book></work>
+			</results></search></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/author/show/1?key=%s", testAPIKey),
+			response:  `<response><author><id>1</id><fans_count>10</fans_count><author_followers>5</author_followers></author></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/author/show/2?key=%s", testAPIKey),
+			response:  `<response><author><id>2</id><fans_count>10</fans_count><author_followers>50</author_followers></author></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/author/show/3?key=%s", testAPIKey),
+			response:  `<response><author><id>3</id><fans_count>900</fans_count></author></response>`,
+		},
+	)
+	defer done()
+
+	authors, err := c.PopularAuthors("fantasy", 1)
+	assert.Nil(t, err)
+	var ids []string
+	for _, a := range authors {
+		ids = append(ids, a.ID)
+	}
+	assert.Equal(t, []string{"3", "2", "1"}, ids)
+}
+
+func TestClient_ResolveBook(t *testing.T) {
+	dune := `<response><book><id>234225</id><title>Dune</title></book></response>`
+	search := `<response><search><results><work><best_book><id>234225</id></best_book></work></results></search></response>`
+	testCases := []struct {
+		name  string
+		query string
+		cases []decodeTestCase
+	}{
+		{"by ID", "234225", []decodeTestCase{
+			{expectURL: fmt.Sprintf("/book/show/234225.xml?key=%s", testAPIKey), response: dune},
+		}},
+		{"by ISBN", "0-441-17271-7", []decodeTestCase{
+			{expectURL: fmt.Sprintf("/book/isbn/0441172717?format=xml&key=%s", testAPIKey), response: dune},
+		}},
+		{"by ISBN-13", "9780441172719", []decodeTestCase{
+			{expectURL: fmt.Sprintf("/book/isbn/9780441172719?format=xml&key=%s", testAPIKey), response: dune},
+		}},
+		{"by ISBN-10 that is also an ID", "0441172717", []decodeTestCase{
+			{expectURL: fmt.Sprintf("/book/show/0441172717.xml?key=%s", testAPIKey), response: `<response><book><id>441172717</id><title>Not Dune</title></book></response>`},
+			{expectURL: fmt.Sprintf("/book/isbn/0441172717?format=xml&key=%s", testAPIKey), response: dune},
+		}},
+		{"by title", "dune", []decodeTestCase{
+			{expectURL: fmt.Sprintf("/search/index.xml?key=%s&page=1&q=dune&search%%5Bfield%%5D=all", testAPIKey), response: search},
+			{expectURL: fmt.Sprintf("/book/show/234225.xml?key=%s", testAPIKey), response: dune},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, done := newRoutedTestClient(t, tc.cases...)
+			defer done()
+
+			b, err := c.ResolveBook(context.Background(), tc.query)
+			assert.Nil(t, err)
+			assert.Equal(t, "Dune", b.Title)
+		})
+	}
+
+	t.Run("no match", func(t *testing.T) {
+		c, done := newRoutedTestClient(t, decodeTestCase{
+			expectURL: fmt.Sprintf("/search/index.xml?key=%s&page=1&q=nothing&search%%5Bfield%%5D=all", testAPIKey),
+			response:  `<response><search><results></results></search></response>`,
+		})
+		defer done()
+
+		b, err := c.ResolveBook(context.Background(), "nothing")
+		assert.Nil(t, b)
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestClient_CanReview(t *testing.T) {
+	book := decodeTestCase{
+		expectURL: fmt.Sprintf("/book/show/50.xml?key=%s", testAPIKey),
+		response:  `<response><book><id>50</id><title>Hatchet</title></book></response>`,
+	}
+
+	t.Run("not reviewed", func(t *testing.T) {
+		c, done := newAuthedTestClient(t, book)
+		defer done()
+
+		ok, r, err := c.CanReview(context.Background(), "50")
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Nil(t, r)
+	})
+
+	t.Run("already reviewed", func(t *testing.T) {
+		c, done := newAuthedTestClient(t, book, decodeTestCase{
+			expectURL: fmt.Sprintf("/review/show_by_user_and_book.xml?book_id=50&key=%s&user_id=1", testAPIKey),
+			response:  `<response><review><id>21</id><rating>4</rating></review></response>`,
+		})
+		defer done()
+
+		ok, r, err := c.CanReview(context.Background(), "50")
+		assert.Nil(t, err)
+		assert.False(t, ok)
+		if assert.NotNil(t, r) {
+			assert.Equal(t, "21", r.ID)
+		}
+	})
+
+	t.Run("no such book", func(t *testing.T) {
+		c, done := newAuthedTestClient(t)
+		defer done()
+
+		ok, r, err := c.CanReview(context.Background(), "50")
+		assert.Equal(t, ErrNotFound, err)
+		assert.False(t, ok)
+		assert.Nil(t, r)
+	})
+
+	t.Run("no access token", func(t *testing.T) {
+		c, done := newRoutedTestClient(t, book)
+		defer done()
+
+		_, _, err := c.CanReview(context.Background(), "50")
+		assert.Equal(t, ErrNoAccessToken, err)
+	})
+}
+
 func TestClient_ReviewList(t *testing.T) {
 	c, done := newTestClient(t, decodeTestCase{
-		expectURL: fmt.Sprintf("/review/list/user-id.xml?key=%s&order=d&page=1&per_page=200&search=search&shelf=read&sort=date_read&v=2", testAPIKey),
+		expectURL: fmt.Sprintf("/review/list/38763538.xml?key=%s&order=d&page=1&per_page=200&search=search&shelf=read&sort=date_read&v=2", testAPIKey),
 		response: `<response>
 			<reviews>
 				<review><id>review1</id><rating>1</rating></review>
@@ -102,15 +568,192 @@ func TestClient_ReviewList(t *testing.T) {
 	})
 	defer done()
 
-	r, err := c.ReviewList("user-id", "read", "date_read", "search", "d", 1, 200)
+	r, err := c.ReviewList("38763538", "read", "date_read", "search", "d", 1, 200)
 	assert.Nil(t, err)
 	assert.Equal(t, []responses.Review{
-		{ID: "review1", Rating: 1},
-		{ID: "review2", Rating: 2},
-		{ID: "review3", Rating: 3},
+		{ID: "review1", Rating: 1, HasRating: true},
+		{ID: "review2", Rating: 2, HasRating: true},
+		{ID: "review3", Rating: 3, HasRating: true},
 	}, r)
 }
 
+func TestClient_ReviewList_authorRoles(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/38763538.xml?key=%s&v=2", testAPIKey),
+		response: `<GoodreadsResponse>
+			<reviews start="1" end="1" total="1">
+				<review>
+					<id>review1</id>
+					<book>
+						<id type="integer">2657</id>
+						<title>The Hobbit</title>
+						<authors>
+							<author>
+								<id>656983</id>
+								<name>J.R.R. Tolkien</name>
+								<role></role>
+								<image_url nophoto='false'>https://images.gr-assets.com/authors/1.jpg</image_url>
+								<link>https://www.goodreads.com/author/show/656983.J_R_R_Tolkien</link>
+								<average_rating>4.35</average_rating>
+								<ratings_count>1</ratings_count>
+								<text_reviews_count>2</text_reviews_count>
+							</author>
+							<author>
+								<id>7567</id>
+								<name>Alan Lee</name>
+								<role>Illustrator</role>
+							</author>
+						</authors>
+					</book>
+				</review>
+			</reviews>
+		</GoodreadsResponse>`,
+	})
+	defer done()
+
+	reviews, err := c.ReviewList("38763538", "", "", "", "", 0, 0)
+	assert.Nil(t, err)
+	if assert.Len(t, reviews, 1) {
+		authors := reviews[0].Book.Authors
+		if assert.Len(t, authors, 2) {
+			assert.Equal(t, "656983", authors[0].ID)
+			assert.Equal(t, "J.R.R. Tolkien", authors[0].Name)
+			assert.Equal(t, "", authors[0].Role)
+			assert.Equal(t, "7567", authors[1].ID)
+			assert.Equal(t, "Alan Lee", authors[1].Name)
+			assert.Equal(t, "Illustrator", authors[1].Role)
+		}
+	}
+}
+
+func TestClient_ReviewShow(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/show.xml?id=21&key=%s", testAPIKey),
+		response: `<response>
+			<review>
+				<id>21</id>
+				<book><id>50</id></book>
+				<rating>4</rating>
+				<spoiler_flag>true</spoiler_flag>
+				<spoilers_state>hidden</spoilers_state>
+				<comments_count>3</comments_count>
+			</review>
+		</response>`,
+	})
+	defer done()
+
+	r, err := c.ReviewShow("21")
+	assert.Nil(t, err)
+	assert.Equal(t, responses.Review{
+		ID:            "21",
+		Book:          responses.AuthorBook{ID: "50"},
+		Rating:        4,
+		HasRating:     true,
+		SpoilerFlag:   true,
+		SpoilersState: "hidden",
+		CommentsCount: 3,
+	}, *r)
+}
+
+func TestClient_ReviewShowRaw(t *testing.T) {
+	body := `<response><review><id>21</id><book><id>50</id></book><rating>4</rating><votes>7</votes></review></response>`
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/show.xml?id=21&key=%s", testAPIKey),
+		response:  body,
+	})
+	defer done()
+
+	raw, r, err := c.ReviewShowRaw(context.Background(), "21")
+	assert.Nil(t, err)
+	assert.Equal(t, body, string(raw))
+	assert.Equal(t, responses.Review{
+		ID:        "21",
+		Book:      responses.AuthorBook{ID: "50"},
+		Rating:    4,
+		HasRating: true,
+	}, *r)
+}
+
+func TestClient_ReviewShowRawNotFound(t *testing.T) {
+	c, done := newRoutedTestClient(t)
+	defer done()
+
+	raw, r, err := c.ReviewShowRaw(context.Background(), "21")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Nil(t, raw)
+	assert.Nil(t, r)
+}
+
+func TestClient_ReviewShowRawContentType(t *testing.T) {
+	body := `{"review": {"id": "21", "rating": 4}}`
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer s.Close()
+	c := &Client{APIKey: testAPIKey, httpClient: &httpClient{Client: http.DefaultClient, APIRoot: s.URL}}
+
+	parsed, err := c.ReviewShow("21")
+	assert.Nil(t, err)
+	raw, r, err := c.ReviewShowRaw(context.Background(), "21")
+	assert.Nil(t, err)
+	assert.Equal(t, body, string(raw))
+	assert.Equal(t, responses.Review{ID: "21", Rating: 4}, *r)
+	assert.Equal(t, parsed, r)
+}
+
+func TestClient_ReviewShowByUserAndBook(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/show_by_user_and_book.xml?book_id=50&key=%s&user_id=1", testAPIKey),
+		response:  `<response><review><id>21</id><book><id>50</id></book><rating>4</rating></review></response>`,
+	})
+	defer done()
+
+	r, err := c.ReviewShowByUserAndBook("1", "50")
+	assert.Nil(t, err)
+	assert.Equal(t, responses.Review{
+		ID:        "21",
+		Book:      responses.AuthorBook{ID: "50"},
+		Rating:    4,
+		HasRating: true,
+	}, *r)
+}
+
+func TestClient_SameWork(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/id_to_work_id/1,2?key=%s", testAPIKey),
+			response:  `<response><work-ids><item>100</item><item>100</item></work-ids></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/id_to_work_id/3?key=%s", testAPIKey),
+			response:  `<response><work-ids><item>300</item></work-ids></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/id_to_work_id/4?key=%s", testAPIKey),
+			response:  `<response><work-ids><item></item></work-ids></response>`,
+		},
+	)
+	defer done()
+
+	same, err := c.SameWork(context.Background(), "1", "2.Hatchet")
+	assert.Nil(t, err)
+	assert.True(t, same)
+
+	// 1 is cached, so only 3 is requested.
+	same, err = c.SameWork(context.Background(), "1", "3")
+	assert.Nil(t, err)
+	assert.False(t, same)
+
+	_, err = c.SameWork(context.Background(), "1", "abc")
+	assert.True(t, errors.Is(err, ErrInvalidID))
+	assert.Contains(t, err.Error(), `"abc"`)
+
+	_, err = c.SameWork(context.Background(), "4", "1")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Contains(t, err.Error(), `"4"`)
+}
+
 func TestClient_SearchBooks(t *testing.T) {
 	c, done := newTestClient(t, decodeTestCase{
 		expectURL: fmt.Sprintf("/search/index.xml?key=%s&page=1&q=hello&search%%5Bfield%%5D=all", testAPIKey),
@@ -207,9 +850,246 @@ func TestClient_SearchBooks(t *testing.T) {
 	}, books)
 }
 
+func TestClient_SearchBooks_bestBookAuthor(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/search/index.xml?key=%s&q=hatchet&search%%5Bfield%%5D=title", testAPIKey),
+		response: `<?xml version="1.0" encoding="UTF-8"?>
+		<GoodreadsResponse>
+		  <Request>
+			<authentication>true</authentication>
+			<key><![CDATA[key]]></key>
+			<method><![CDATA[search_index]]></method>
+		  </Request>
+		  <search>
+			<query><![CDATA[hatchet]]></query>
+			<results-start>1</results-start>
+			<results-end>1</results-end>
+			<total-results>1</total-results>
+			<source>Goodreads</source>
+			<query-time-seconds>0.05</query-time-seconds>
+			<results>
+			  <work>
+				<id type="integer">1158125</id>
+				<books_count type="integer">254</books_count>
+				<ratings_count type="integer">330284</ratings_count>
+				<text_reviews_count type="integer">11738</text_reviews_count>
+				<original_publication_year type="integer">1986</original_publication_year>
+				<original_publication_month type="integer" nil="true"/>
+				<original_publication_day type="integer" nil="true"/>
+				<average_rating>3.71</average_rating>
+				<best_book type="Book">
+				  <id type="integer">50</id>
+				  <title>Hatchet (Brian's Saga, #1)</title>
+				  <author>
+					<id type="integer">18</id>
+					<name>Gary Paulsen</name>
+				  </author>
+				  <image_url>https://images.gr-assets.com/books/1358749838m/50.jpg</image_url>
+				  <small_image_url>https://images.gr-assets.com/books/1358749838s/50.jpg</small_image_url>
+				</best_book>
+			  </work>
+			</results>
+		  </search>
+		</GoodreadsResponse>`,
+	})
+	defer done()
+
+	works, err := c.SearchBooks("hatchet", 0, TitleField)
+	assert.Nil(t, err)
+	if assert.Len(t, works, 1) {
+		assert.Equal(t, 50, works[0].BestBook.ID)
+		assert.Equal(t, work.Author{ID: 18, Name: "Gary Paulsen"}, works[0].BestBook.Author)
+		assert.Equal(t, 0, works[0].OriginalPublicationMonth)
+	}
+}
+
+func TestClient_SeriesShow(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/series/show/40321.xml?key=%s", testAPIKey),
+		response: `<response>
+			<series>
+				<id>40321</id>
+				<title>Dune</title>
+				<numbered>true</numbered>
+				<series_works>
+					<series_work><id>1</id><user_position>1</user_position><work><id>3634639</id></work></series_work>
+					<series_work><id>2</id><user_position>2</user_position><work><id>3634570</id></work></series_work>
+				</series_works>
+			</series>
+		</response>`,
+	})
+	defer done()
+
+	s, err := c.SeriesShow("40321")
+	assert.Nil(t, err)
+	assert.Equal(t, responses.Series{
+		ID:       "40321",
+		Title:    "Dune",
+		Numbered: true,
+		SeriesWorks: []responses.SeriesWork{
+			{ID: "1", UserPosition: "1", Work: work.Work{ID: 3634639}},
+			{ID: "2", UserPosition: "2", Work: work.Work{ID: 3634570}},
+		},
+	}, *s)
+}
+
+func TestClient_SeriesShowSortsByPosition(t *testing.T) {
+	response := `<response><series><id>1</id><series_works>
+		<series_work><id>a</id><user_position>4</user_position></series_work>
+		<series_work><id>b</id><user_position>1-3</user_position></series_work>
+		<series_work><id>c</id><user_position>3.5</user_position></series_work>
+		<series_work><id>d</id><user_position>1</user_position></series_work>
+		<series_work><id>e</id><user_position>10</user_position></series_work>
+		<series_work><id>f</id><user_position>3</user_position></series_work>
+		<series_work><id>g</id><user_position></user_position></series_work>
+	</series_works></series></response>`
+
+	testCases := []struct {
+		name   string
+		opts   []Option
+		expect []string
+	}{
+		{"sorted", nil, []string{"d", "f", "c", "a", "e", "b", "g"}},
+		{"api order", []Option{WithSeriesAPIOrder()}, []string{"a", "b", "c", "d", "e", "f", "g"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, done := newTestClient(t, decodeTestCase{
+				expectURL: fmt.Sprintf("/series/show/1.xml?key=%s", testAPIKey),
+				response:  response,
+			})
+			defer done()
+			for _, opt := range tc.opts {
+				opt(c)
+			}
+
+			s, err := c.SeriesShow("1")
+			assert.Nil(t, err)
+			var ids []string
+			for _, sw := range s.SeriesWorks {
+				ids = append(ids, sw.ID)
+			}
+			assert.Equal(t, tc.expect, ids)
+		})
+	}
+}
+
+func TestClient_SeriesNeighbors(t *testing.T) {
+	book := func(id, position string) decodeTestCase {
+		return decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/%s.xml?key=%s", id, testAPIKey),
+			response: fmt.Sprintf(`<response><book><id>%s</id>
+				<series_works><series_work><user_position>%s</user_position><series><id>40321</id></series></series_work></series_works>
+			</book></response>`, id, position),
+		}
+	}
+	c, done := newRoutedTestClient(t,
+		book("1", "1"),
+		book("2", "2"),
+		book("3", "3"),
+		book("35", "3.5"),
+		book("9", "omnibus"),
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/series/show/40321.xml?key=%s", testAPIKey),
+			response: `<response><series><id>40321</id><series_works>
+				<series_work><user_position>3</user_position><work><best_book><id>3</id></best_book></work></series_work>
+				<series_work><user_position>1</user_position><work><best_book><id>1</id></best_book></work></series_work>
+				<series_work><user_position>3.5</user_position><work><best_book><id>35</id></best_book></work></series_work>
+				<series_work><user_position>omnibus</user_position><work><best_book><id>9</id></best_book></work></series_work>
+				<series_work><user_position>2</user_position><work><best_book><id>2</id></best_book></work></series_work>
+			</series_works></series></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/8.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>8</id></book></response>`,
+		},
+	)
+	defer done()
+
+	testCases := []struct {
+		bookID, prev, next string
+	}{
+		{"1", "", "2"},
+		{"2", "1", "3"},
+		{"3", "2", "35"},
+		{"35", "3", ""},
+	}
+	for _, tc := range testCases {
+		prev, next, err := c.SeriesNeighbors(context.Background(), tc.bookID)
+		assert.Nil(t, err, tc.bookID)
+		if tc.prev == "" {
+			assert.Nil(t, prev, tc.bookID)
+		} else if assert.NotNil(t, prev, tc.bookID) {
+			assert.Equal(t, tc.prev, prev.ID, tc.bookID)
+		}
+		if tc.next == "" {
+			assert.Nil(t, next, tc.bookID)
+		} else if assert.NotNil(t, next, tc.bookID) {
+			assert.Equal(t, tc.next, next.ID, tc.bookID)
+		}
+	}
+
+	for _, id := range []string{"9", "8"} {
+		_, _, err := c.SeriesNeighbors(context.Background(), id)
+		assert.Equal(t, ErrNotFound, err, id)
+	}
+}
+
+func TestClient_SeriesStart(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/44492285.xml?key=%s", testAPIKey),
+			response: `<response><book><id>44492285</id><title>Dune Messiah</title>
+				<series_works><series_work><user_position>2</user_position><series><id>40321</id></series></series_work></series_works>
+			</book></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/series/show/40321.xml?key=%s", testAPIKey),
+			response: `<response><series><id>40321</id><series_works>
+				<series_work><user_position>2</user_position><work><best_book><id>44492285</id></best_book></work></series_work>
+				<series_work><user_position>0.5</user_position><work><best_book><id>7</id></best_book></work></series_work>
+				<series_work><user_position>1</user_position><work><best_book><id>234225</id></best_book></work></series_work>
+				<series_work><user_position></user_position><work><best_book><id>8</id></best_book></work></series_work>
+			</series_works></series></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/234225.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>234225</id><title>Dune</title></book></response>`,
+		},
+	)
+	defer done()
+
+	b, err := c.SeriesStart(context.Background(), "44492285")
+	assert.Nil(t, err)
+	assert.Equal(t, "Dune", b.Title)
+
+	_, err = c.SeriesStart(context.Background(), "234225")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestClient_ShelfTagCloud(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/shelf/list.xml?key=%s&user_id=38763538", testAPIKey),
+		response: `<response>
+			<shelves>
+				<user_shelf><name>read</name><book_count type="integer">412</book_count><exclusive_flag type="boolean">true</exclusive_flag></user_shelf>
+				<user_shelf><name>sci-fi</name><book_count type="integer">37</book_count><exclusive_flag type="boolean">false</exclusive_flag></user_shelf>
+				<user_shelf><name>favourites</name><book_count type="integer">12</book_count><exclusive_flag type="boolean">false</exclusive_flag></user_shelf>
+				<user_shelf><name>to-read</name><book_count type="integer">90</book_count><exclusive_flag type="boolean">true</exclusive_flag></user_shelf>
+			</shelves>
+		</response>`,
+	})
+	defer done()
+
+	cloud, err := c.ShelfTagCloud("38763538")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"sci-fi": 37, "favourites": 12}, cloud)
+}
+
 func TestClient_ShelvesList(t *testing.T) {
 	c, done := newTestClient(t, decodeTestCase{
-		expectURL: fmt.Sprintf("/shelf/list.xml?key=%s&user_id=user-id", testAPIKey),
+		expectURL: fmt.Sprintf("/shelf/list.xml?key=%s&user_id=38763538", testAPIKey),
 		response: `<response>
 			<shelves>
 				<user_shelf><id>shelf1</id><name>Shelf 1</name></user_shelf>
@@ -220,7 +1100,7 @@ func TestClient_ShelvesList(t *testing.T) {
 	})
 	defer done()
 
-	s, err := c.ShelvesList("user-id")
+	s, err := c.ShelvesList("38763538")
 	assert.Nil(t, err)
 	assert.Equal(t, []responses.UserShelf{
 		{ID: "shelf1", Name: "Shelf 1"},
@@ -231,7 +1111,7 @@ func TestClient_ShelvesList(t *testing.T) {
 
 func TestClient_UserShow(t *testing.T) {
 	c, done := newTestClient(t, decodeTestCase{
-		expectURL: fmt.Sprintf("/user/show/user-id.xml?key=%s", testAPIKey),
+		expectURL: fmt.Sprintf("/user/show/38763538.xml?key=%s", testAPIKey),
 		response: `<response>
 			<user>
 				<id>user-id</id>
@@ -241,7 +1121,7 @@ func TestClient_UserShow(t *testing.T) {
 	})
 	defer done()
 
-	u, err := c.UserShow("user-id")
+	u, err := c.UserShow("38763538")
 	assert.Nil(t, err)
 	assert.Equal(t, responses.User{
 		ID:   "user-id",
@@ -249,6 +1129,121 @@ func TestClient_UserShow(t *testing.T) {
 	}, *u)
 }
 
+func TestClient_InvalidID(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL)
+	}))
+	defer s.Close()
+	c := &Client{APIKey: testAPIKey, httpClient: &httpClient{Client: http.DefaultClient, APIRoot: s.URL}}
+
+	for _, id := range []string{"", " ", "abc", "Tim_O_Reilly", ".18541", "12a34"} {
+		calls := map[string]func() error{
+			"AuthorBooks": func() error { _, err := c.AuthorBooks(id, 1); return err },
+			"AuthorShow":  func() error { _, err := c.AuthorShow(id); return err },
+			"BookShow":    func() error { _, err := c.BookShow(id); return err },
+			"IDToWorkID":  func() error { _, err := c.IDToWorkID([]string{"50", id}); return err },
+			"ReviewList":  func() error { _, err := c.ReviewList(id, "", "", "", "", 1, 20); return err },
+			"ReviewShow":  func() error { _, err := c.ReviewShow(id); return err },
+			"ReviewShowByUserAndBook": func() error {
+				_, err := c.ReviewShowByUserAndBook("1", id)
+				return err
+			},
+			"SeriesShow":   func() error { _, err := c.SeriesShow(id); return err },
+			"ShelvesList":  func() error { _, err := c.ShelvesList(id); return err },
+			"UserShow":     func() error { _, err := c.UserShow(id); return err },
+			"WorkEditions": func() error { _, err := c.WorkEditions(id, 1); return err },
+		}
+		for method, call := range calls {
+			err := call()
+			assert.True(t, errors.Is(err, ErrInvalidID), "%s(%q): expected ErrInvalidID, got %v", method, id, err)
+		}
+	}
+}
+
+func TestClient_SluggedID(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/author/show/18541?key=%s", testAPIKey),
+		response:  `<response><author><id>18541</id></author></response>`,
+	})
+	defer done()
+
+	a, err := c.AuthorShow("18541.Tim_O_Reilly")
+	assert.Nil(t, err)
+	assert.Equal(t, "18541", a.ID)
+}
+
+func TestClient_Concurrent(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/author/show/18541?key=%s", testAPIKey),
+			response:  `<response><author><id>18541</id></author></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/show/50.xml?key=%s", testAPIKey),
+			response:  `<response><book><id>50</id></book></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/user/show/38763538.xml?key=%s", testAPIKey),
+			response:  `<response><user><id>38763538</id></user></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/shelf/list.xml?key=%s&user_id=38763538", testAPIKey),
+			response:  `<response><shelves><user_shelf><id>1</id></user_shelf></shelves></response>`,
+		},
+	)
+	defer done()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 25; i++ {
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			a, err := c.AuthorShow("18541")
+			assert.Nil(t, err)
+			assert.Equal(t, "18541", a.ID)
+		}()
+		go func() {
+			defer wg.Done()
+			b, err := c.BookShow("50")
+			assert.Nil(t, err)
+			assert.Equal(t, "50", b.ID)
+		}()
+		go func() {
+			defer wg.Done()
+			u, err := c.UserShow("38763538")
+			assert.Nil(t, err)
+			assert.Equal(t, "38763538", u.ID)
+		}()
+		go func() {
+			defer wg.Done()
+			s, err := c.ShelvesList("38763538")
+			assert.Nil(t, err)
+			assert.Len(t, s, 1)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClient_WorkEditions(t *testing.T) {
+	c, done := newTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/work/editions/1158125?format=xml&key=%s&page=2", testAPIKey),
+		response: `<response>
+			<editions>
+				<book><id>50</id><format>Paperback</format></book>
+				<book><id>51</id><format>Audiobook</format></book>
+			</editions>
+		</response>`,
+	})
+	defer done()
+
+	e, err := c.WorkEditions("1158125", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []responses.Book{
+		{ID: "50", Format: "Paperback"},
+		{ID: "51", Format: "Audiobook"},
+	}, e)
+}
+
 type decodeTestCase struct {
 	expectURL string
 	response  string
@@ -269,3 +1264,25 @@ func newTestClient(t *testing.T, tc decodeTestCase) (*Client, func()) {
 		},
 	}, s.Close
 }
+
+// newRoutedTestClient returns a Client backed by a server that responds to
+// each of the expected URLs, and with a 404 to anything else.
+func newRoutedTestClient(t *testing.T, tcs ...decodeTestCase) (*Client, func()) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, tc := range tcs {
+			if tc.expectURL == r.URL.String() {
+				_, _ = w.Write([]byte(tc.response))
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}))
+
+	return &Client{
+		APIKey: testAPIKey,
+		httpClient: &httpClient{
+			Client:  http.DefaultClient,
+			APIRoot: s.URL,
+		},
+	}, s.Close
+}
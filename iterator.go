@@ -0,0 +1,92 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+
+	"github.com/KyleBanks/goodreads/responses"
+)
+
+// ErrNoMorePages is returned by an iterator once every page has been
+// returned, or when asked for a page beyond the last one.
+var ErrNoMorePages = errors.New("no more pages")
+
+// ReviewIterator pages through the reviews on a user's shelf. It is not safe
+// for concurrent use.
+type ReviewIterator struct {
+	c                          *Client
+	ctx                        context.Context
+	userID, shelf, sort, order string
+	perPage                    int
+
+	next       int
+	done       bool
+	pagination responses.Pagination
+}
+
+// ReviewListIterator returns an iterator over the reviews on a user's shelf,
+// in the given sort and order, perPage reviews at a time. The parameters
+// are the same as those of ReviewList.
+func (c *Client) ReviewListIterator(ctx context.Context, userID, shelf, sort, order string, perPage int) *ReviewIterator {
+	if perPage <= 0 || perPage > reviewsPerPage {
+		perPage = reviewsPerPage
+	}
+	return &ReviewIterator{
+		c:       c,
+		ctx:     ctx,
+		userID:  userID,
+		shelf:   shelf,
+		sort:    sort,
+		order:   order,
+		perPage: perPage,
+		next:    1,
+	}
+}
+
+// Next returns the next page of reviews, or ErrNoMorePages once the shelf
+// is exhausted.
+func (it *ReviewIterator) Next() ([]responses.Review, error) {
+	if it.done {
+		return nil, ErrNoMorePages
+	}
+	return it.Page(it.next)
+}
+
+// Page returns page n, counting from 1, and positions the iterator so that
+// Next returns the page after it. ErrNoMorePages is returned, without a
+// request when the total is already known, if n is beyond the last page.
+func (it *ReviewIterator) Page(n int) ([]responses.Review, error) {
+	if n < 1 {
+		n = 1
+	}
+	if it.pagination.Total > 0 && n > it.TotalPages() {
+		it.next, it.done = n, true
+		return nil, ErrNoMorePages
+	}
+
+	reviews, p, err := it.c.reviewList(it.ctx, it.userID, it.shelf, it.sort, "", it.order, n, it.perPage)
+	if err != nil {
+		return nil, err
+	}
+	if p.Total > 0 {
+		it.pagination = p
+	}
+
+	it.next = n + 1
+	it.done = len(reviews) < it.perPage || (p.Total > 0 && p.End >= p.Total)
+	if len(reviews) == 0 {
+		return nil, ErrNoMorePages
+	}
+	return reviews, nil
+}
+
+// Pagination returns the pagination of the most recently fetched page.
+func (it *ReviewIterator) Pagination() responses.Pagination {
+	return it.pagination
+}
+
+// TotalPages returns the number of pages on the shelf, or 0 if it is not yet
+// known because no page has been fetched.
+func (it *ReviewIterator) TotalPages() int {
+	return it.pagination.TotalPages(it.perPage)
+}
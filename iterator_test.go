@@ -0,0 +1,90 @@
+package goodreads
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func iteratorPage(page, start, end, total int, ids ...string) decodeTestCase {
+	var reviews string
+	for _, id := range ids {
+		reviews += fmt.Sprintf("<review><id>%s</id></review>", id)
+	}
+	return decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=%d&per_page=2&v=2", testAPIKey, page),
+		response:  fmt.Sprintf(`<response><reviews start="%d" end="%d" total="%d">%s</reviews></response>`, start, end, total, reviews),
+	}
+}
+
+func TestReviewIterator_Next(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		iteratorPage(1, 1, 2, 3, "a", "b"),
+		iteratorPage(2, 3, 3, 3, "c"),
+	)
+	defer done()
+
+	it := c.ReviewListIterator(context.Background(), "1", "", "", "", 2)
+	assert.Equal(t, 0, it.TotalPages())
+
+	r, err := it.Next()
+	assert.Nil(t, err)
+	assert.Len(t, r, 2)
+	assert.Equal(t, 2, it.TotalPages())
+
+	r, err = it.Next()
+	assert.Nil(t, err)
+	assert.Len(t, r, 1)
+	assert.Equal(t, 3, it.Pagination().Start)
+
+	r, err = it.Next()
+	assert.Equal(t, ErrNoMorePages, err)
+	assert.Nil(t, r)
+}
+
+func TestReviewIterator_Page(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		iteratorPage(1, 1, 2, 5, "a", "b"),
+		iteratorPage(2, 3, 4, 5, "c", "d"),
+		iteratorPage(3, 5, 5, 5, "e"),
+	)
+	defer done()
+
+	it := c.ReviewListIterator(context.Background(), "1", "", "", "", 2)
+
+	r, err := it.Page(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "c", r[0].ID)
+	assert.Equal(t, 3, it.TotalPages())
+
+	r, err = it.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "e", r[0].ID)
+
+	r, err = it.Page(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", r[0].ID)
+
+	r, err = it.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "c", r[0].ID)
+
+	// Beyond the known total, no request is made.
+	r, err = it.Page(10)
+	assert.Equal(t, ErrNoMorePages, err)
+	assert.Nil(t, r)
+	_, err = it.Next()
+	assert.Equal(t, ErrNoMorePages, err)
+}
+
+func TestReviewIterator_PageBeyondUnknownTotal(t *testing.T) {
+	c, done := newRoutedTestClient(t, iteratorPage(7, 0, 0, 3))
+	defer done()
+
+	it := c.ReviewListIterator(context.Background(), "1", "", "", "", 2)
+	r, err := it.Page(7)
+	assert.Equal(t, ErrNoMorePages, err)
+	assert.Nil(t, r)
+}
@@ -0,0 +1,594 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/KyleBanks/goodreads/responses"
+)
+
+// reviewsPerPage is the largest page size reviews.list accepts.
+const reviewsPerPage = 200
+
+// errStopWalk is returned by a walkReviews callback to stop paging early
+// without error.
+var errStopWalk = errors.New("stop walk")
+
+// walkReviews pages through the reviews on a user's shelf, in the given sort
+// and order, calling fn with each page until the shelf is exhausted or fn
+// returns an error. An empty shelf lists every shelf.
+func (c *Client) walkReviews(ctx context.Context, userID, shelf, sort, order string, fn func([]responses.Review) error) error {
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		reviews, p, err := c.reviewList(ctx, userID, shelf, sort, "", order, page, reviewsPerPage)
+		if err != nil {
+			return err
+		}
+		if len(reviews) > 0 {
+			if err := fn(reviews); err == errStopWalk {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+		if len(reviews) < reviewsPerPage || (p.Total > 0 && p.End >= p.Total) {
+			return nil
+		}
+	}
+}
+
+// FindDuplicateEditions returns the reviews of a user that are of the same
+// work, keyed by work ID. Only works with more than one shelved edition are
+// included.
+func (c *Client) FindDuplicateEditions(ctx context.Context, userID string) (map[string][]responses.Review, error) {
+	var reviews []responses.Review
+	err := c.walkReviews(ctx, userID, "", "", "", func(page []responses.Review) error {
+		reviews = append(reviews, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bookIDs := make([]string, len(reviews))
+	for i, r := range reviews {
+		bookIDs[i] = r.Book.ID
+	}
+	workIDs, err := c.idToWorkID(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byWork := make(map[string][]responses.Review)
+	for i, r := range reviews {
+		if workIDs[i] != "" {
+			byWork[workIDs[i]] = append(byWork[workIDs[i]], r)
+		}
+	}
+	for w, rs := range byWork {
+		if len(rs) < 2 {
+			delete(byWork, w)
+		}
+	}
+	return byWork, nil
+}
+
+// AuthorCount is an author along with the number of a user's books they
+// wrote.
+type AuthorCount struct {
+	Author responses.Author
+	Count  int
+}
+
+// TopAuthors returns the authors a user has read the most books by, with
+// the number of books read, most read first. At most topN authors are
+// returned, or all of them if topN is zero or less. Every author credited
+// on a book is counted.
+func (c *Client) TopAuthors(ctx context.Context, userID string, topN int) ([]AuthorCount, error) {
+	counts := make(map[string]*AuthorCount)
+	err := c.walkReviews(ctx, userID, "read", "", "", func(reviews []responses.Review) error {
+		for _, r := range reviews {
+			for _, a := range r.Book.Authors {
+				key := a.ID
+				if key == "" {
+					key = "name:" + a.Name
+				}
+				if counts[key] == nil {
+					counts[key] = &AuthorCount{Author: a}
+				}
+				counts[key].Count++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	top := make([]AuthorCount, 0, len(counts))
+	for _, ac := range counts {
+		top = append(top, *ac)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Author.Name < top[j].Author.Name
+	})
+	if topN > 0 && len(top) > topN {
+		top = top[:topN]
+	}
+	return top, nil
+}
+
+// RecentlyShelved returns up to limit of a user's most recently shelved
+// books, newest first. Fewer are returned if the user has shelved fewer
+// books.
+func (c *Client) RecentlyShelved(ctx context.Context, userID string, limit int) ([]responses.Review, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	var recent []responses.Review
+	err := c.walkReviews(ctx, userID, "", "date_added", "d", func(reviews []responses.Review) error {
+		recent = append(recent, reviews...)
+		if len(recent) >= limit {
+			return errStopWalk
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(recent) > limit {
+		recent = recent[:limit]
+	}
+	return recent, nil
+}
+
+// ShelfEvents polls a user's shelves every interval, calling fn with each
+// book shelved after since, oldest first. The date added of the newest book
+// seen becomes the mark for the next poll, so a book is reported once.
+//
+// ShelfEvents runs until ctx is done, a poll fails or fn returns an error,
+// and returns that error. Reviews whose date added cannot be parsed are
+// skipped.
+func (c *Client) ShelfEvents(ctx context.Context, userID string, since time.Time, interval time.Duration, fn func(responses.Review) error) error {
+	for {
+		var added []responses.Review
+		err := c.walkReviews(ctx, userID, "", "date_added", "d", func(reviews []responses.Review) error {
+			for _, r := range reviews {
+				t, ok := r.DateAddedTime()
+				if !ok {
+					continue
+				}
+				if !t.After(since) {
+					return errStopWalk
+				}
+				added = append(added, r)
+			}
+			return nil
+		})
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		} else if err != nil {
+			return err
+		}
+
+		for i := len(added) - 1; i >= 0; i-- {
+			if err := fn(added[i]); err != nil {
+				return err
+			}
+			since, _ = added[i].DateAddedTime()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ReviewFilter selects reviews for SmartShelf. The zero value of each field
+// matches every review.
+type ReviewFilter struct {
+	// Shelves limits the reviews to those on any of these shelves.
+	Shelves []string
+
+	// MinRating and MaxRating bound the user's rating, inclusively. Unrated
+	// books have a rating of 0.
+	MinRating, MaxRating int
+
+	// ReadAfter and ReadBefore bound the date read, inclusively. When either
+	// is set, reviews without a date read are excluded.
+	ReadAfter, ReadBefore time.Time
+
+	// AuthorIDs limits the reviews to books by any of these authors.
+	AuthorIDs []string
+}
+
+func (f ReviewFilter) match(r responses.Review) bool {
+	if r.Rating < f.MinRating || (f.MaxRating > 0 && r.Rating > f.MaxRating) {
+		return false
+	}
+	if !f.ReadAfter.IsZero() || !f.ReadBefore.IsZero() {
+		t, ok := r.ReadAtTime()
+		if !ok || t.Before(f.ReadAfter) || (!f.ReadBefore.IsZero() && t.After(f.ReadBefore)) {
+			return false
+		}
+	}
+	if len(f.AuthorIDs) > 0 {
+		for _, a := range r.Book.Authors {
+			for _, id := range f.AuthorIDs {
+				if a.ID == id {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// SmartShelf returns the reviews of a user that match filter, such as
+// "rated 5 and read in 2023". A review on more than one of the filter's
+// shelves is returned once.
+//
+// Goodreads can only list reviews by shelf, with no date range, so every
+// other filter is applied client-side. Without ReadAfter or ReadBefore,
+// every page of the filtered shelves is walked and reviews are returned in
+// the order the API lists them. With either, each shelf is listed by date
+// read, newest first if ReadAfter is set and oldest first otherwise, and
+// paging stops at the first review read outside the range.
+func (c *Client) SmartShelf(ctx context.Context, userID string, filter ReviewFilter) ([]responses.Review, error) {
+	shelves := filter.Shelves
+	if len(shelves) == 0 {
+		shelves = []string{""}
+	}
+
+	var sort, order string
+	var pastRange func(time.Time) bool
+	switch {
+	case !filter.ReadAfter.IsZero():
+		sort, order = "date_read", "d"
+		pastRange = func(t time.Time) bool { return t.Before(filter.ReadAfter) }
+	case !filter.ReadBefore.IsZero():
+		sort, order = "date_read", "a"
+		pastRange = func(t time.Time) bool { return t.After(filter.ReadBefore) }
+	}
+
+	var matched []responses.Review
+	seen := make(map[string]bool)
+	for _, shelf := range shelves {
+		err := c.walkReviews(ctx, userID, shelf, sort, order, func(reviews []responses.Review) error {
+			for _, r := range reviews {
+				if t, ok := r.ReadAtTime(); ok && pastRange != nil && pastRange(t) {
+					return errStopWalk
+				}
+				if seen[r.ID] || !filter.match(r) {
+					continue
+				}
+				seen[r.ID] = true
+				matched = append(matched, r)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return matched, nil
+}
+
+// TimelineEntry is a book a user has read, with their rating and the date
+// they read it.
+type TimelineEntry struct {
+	Book   responses.AuthorBook
+	Rating int
+
+	// ReadAt is the zero time for books without a date read.
+	ReadAt time.Time
+}
+
+// ReadingTimeline returns the books on a user's read shelf, oldest read
+// first. Books without a date read follow the dated ones as a separate
+// group, in the order the API lists them.
+//
+// If ctx is done while paging, the entries of the pages already fetched are
+// returned along with ctx's error.
+func (c *Client) ReadingTimeline(ctx context.Context, userID string) ([]TimelineEntry, error) {
+	var dated, undated []TimelineEntry
+	err := c.walkReviews(ctx, userID, "read", "", "", func(reviews []responses.Review) error {
+		for _, r := range reviews {
+			e := TimelineEntry{Book: r.Book, Rating: r.Rating}
+			if t, ok := r.ReadAtTime(); ok {
+				e.ReadAt = t
+				dated = append(dated, e)
+			} else {
+				undated = append(undated, e)
+			}
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].ReadAt.Before(dated[j].ReadAt)
+	})
+	return append(dated, undated...), ctx.Err()
+}
+
+// UnratedBooks returns the books on a user's shelf that they have not rated,
+// such as for a reminder to rate the books they've read.
+//
+// Goodreads ratings run from 1 to 5, and reviews.list reports unrated books
+// with a rating of 0. Reviews without a rating element at all say nothing
+// about the rating, so they are left out rather than taken as unrated.
+func (c *Client) UnratedBooks(ctx context.Context, userID, shelf string) ([]responses.AuthorBook, error) {
+	var unrated []responses.AuthorBook
+	err := c.walkReviews(ctx, userID, shelf, "", "", func(reviews []responses.Review) error {
+		for _, r := range reviews {
+			if r.HasRating && r.Rating == 0 {
+				unrated = append(unrated, r.Book)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unrated, nil
+}
+
+// ReadCount returns the number of books on a user's read shelf, from the
+// total of a single one-review page rather than by walking the shelf.
+// ErrPrivateProfile is returned if the user's profile is private.
+func (c *Client) ReadCount(ctx context.Context, userID string) (int, error) {
+	_, p, err := c.reviewList(ctx, userID, "read", "", "", "", 1, 1)
+	if err != nil {
+		return 0, err
+	}
+	return p.Total, nil
+}
+
+// Overlap compares the read shelves of two users.
+type Overlap struct {
+	// Similarity is the Jaccard index of the two shelves: the number of
+	// shared works divided by the number of works read by either user.
+	Similarity float64
+
+	// Shared holds the works both users have read, in the order of the
+	// first user's shelf.
+	Shared []SharedBook
+
+	// RatingAgreement is the fraction of the shared books rated by both
+	// users that they rated within one star of each other, or 0 if none
+	// were rated by both.
+	RatingAgreement float64
+}
+
+// SharedBook is a work read by both users compared by LibraryOverlap, with
+// the edition and rating of each. A rating of 0 means unrated.
+type SharedBook struct {
+	BookA, BookB     responses.AuthorBook
+	RatingA, RatingB int
+}
+
+// LibraryOverlap compares what two users have read, using only their public
+// read shelves. Books are matched by work, so different editions of the same
+// book count as shared.
+func (c *Client) LibraryOverlap(ctx context.Context, userA, userB string) (*Overlap, error) {
+	a, err := c.readWorks(ctx, userA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.readWorks(ctx, userB)
+	if err != nil {
+		return nil, err
+	}
+
+	inB := make(map[string]responses.Review, len(b.reviews))
+	for i, r := range b.reviews {
+		inB[b.works[i]] = r
+	}
+
+	o := &Overlap{}
+	var bothRated, agreed int
+	for i, ra := range a.reviews {
+		rb, ok := inB[a.works[i]]
+		if !ok {
+			continue
+		}
+		o.Shared = append(o.Shared, SharedBook{BookA: ra.Book, BookB: rb.Book, RatingA: ra.Rating, RatingB: rb.Rating})
+		if ra.Rating > 0 && rb.Rating > 0 {
+			bothRated++
+			if d := ra.Rating - rb.Rating; d >= -1 && d <= 1 {
+				agreed++
+			}
+		}
+	}
+
+	if union := len(a.reviews) + len(b.reviews) - len(o.Shared); union > 0 {
+		o.Similarity = float64(len(o.Shared)) / float64(union)
+	}
+	if bothRated > 0 {
+		o.RatingAgreement = float64(agreed) / float64(bothRated)
+	}
+	return o, nil
+}
+
+// readWorks holds the reviews on a read shelf, one per work, along with the
+// work ID of each.
+type readWorks struct {
+	reviews []responses.Review
+	works   []string
+}
+
+func (c *Client) readWorks(ctx context.Context, userID string) (readWorks, error) {
+	var reviews []responses.Review
+	err := c.walkReviews(ctx, userID, "read", "", "", func(page []responses.Review) error {
+		reviews = append(reviews, page...)
+		return nil
+	})
+	if err != nil {
+		return readWorks{}, err
+	}
+
+	bookIDs := make([]string, len(reviews))
+	for i, r := range reviews {
+		bookIDs[i] = r.Book.ID
+	}
+	workIDs, err := c.idToWorkID(ctx, bookIDs)
+	if err != nil {
+		return readWorks{}, err
+	}
+
+	var rw readWorks
+	seen := make(map[string]bool)
+	for i, r := range reviews {
+		w := workIDs[i]
+		if w == "" {
+			w = "book:" + r.Book.ID
+		}
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		rw.reviews = append(rw.reviews, r)
+		rw.works = append(rw.works, w)
+	}
+	return rw, nil
+}
+
+// LatestReviewPerBook returns the most recent review of each book on a user's
+// shelf, keyed by book ID, for readers who have reviewed a book more than
+// once. Reviews are ordered by date updated, falling back to date added,
+// and reviews without either date are the oldest.
+func (c *Client) LatestReviewPerBook(ctx context.Context, userID, shelf string) (map[string]responses.Review, error) {
+	latest := make(map[string]responses.Review)
+	err := c.walkReviews(ctx, userID, shelf, "", "", func(reviews []responses.Review) error {
+		for _, r := range reviews {
+			prev, ok := latest[r.Book.ID]
+			if !ok || reviewUpdatedAt(r).After(reviewUpdatedAt(prev)) {
+				latest[r.Book.ID] = r
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
+func reviewUpdatedAt(r responses.Review) time.Time {
+	if t, ok := r.DateUpdatedTime(); ok {
+		return t
+	}
+	t, _ := r.DateAddedTime()
+	return t
+}
+
+// ReadSession is one read of a book, from when it was started to when it
+// was finished. Either date is the zero time when unknown.
+type ReadSession struct {
+	StartedAt, ReadAt time.Time
+
+	// Rating is the user's rating of the book. Goodreads keeps one rating
+	// per book rather than per read, so every session has the same rating.
+	Rating int
+}
+
+// BookReadHistory returns each time a user has read a book, oldest first.
+//
+// Goodreads does not list read sessions, so they are derived from the
+// review's history of statuses: each change to "currently-reading" starts a
+// session, and the next change to "read" finishes it. A review without a
+// history has the single session given by its start and read dates, if any.
+func (c *Client) BookReadHistory(ctx context.Context, userID, bookID string) ([]ReadSession, error) {
+	r, err := c.reviewShowByUserAndBook(ctx, userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := append([]responses.ReadStatus(nil), r.ReadStatuses...)
+	sort.SliceStable(statuses, func(i, j int) bool {
+		a, _ := statuses[i].UpdatedAtTime()
+		b, _ := statuses[j].UpdatedAtTime()
+		return a.Before(b)
+	})
+
+	var sessions []ReadSession
+	var started time.Time
+	for _, s := range statuses {
+		t, _ := s.UpdatedAtTime()
+		switch s.Status {
+		case "currently-reading":
+			started = t
+		case "read":
+			sessions = append(sessions, ReadSession{StartedAt: started, ReadAt: t, Rating: r.Rating})
+			started = time.Time{}
+		}
+	}
+	if !started.IsZero() {
+		sessions = append(sessions, ReadSession{StartedAt: started, Rating: r.Rating})
+	}
+
+	if len(statuses) == 0 {
+		s := ReadSession{Rating: r.Rating}
+		s.StartedAt, _ = r.StartedAtTime()
+		s.ReadAt, _ = r.ReadAtTime()
+		if !s.StartedAt.IsZero() || !s.ReadAt.IsZero() {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+// RatingStats summarizes the ratings a user has given.
+type RatingStats struct {
+	// Rated is the number of reviews with a rating, and Average is their
+	// mean rating, or 0 if none are rated.
+	Rated   int
+	Average float64
+
+	// Distribution counts the reviews with each rating, so Distribution[5]
+	// is the number of 5-star ratings. Distribution[0] counts the unrated
+	// reviews, which are not part of the average.
+	Distribution [6]int
+}
+
+// UserRatingStats returns the average and distribution of the ratings a user
+// has given across all of their shelves.
+func (c *Client) UserRatingStats(ctx context.Context, userID string) (*RatingStats, error) {
+	stats := &RatingStats{}
+	var sum int
+	err := c.walkReviews(ctx, userID, "", "", "", func(reviews []responses.Review) error {
+		for _, r := range reviews {
+			if r.Rating < 0 || r.Rating > 5 {
+				continue
+			}
+			stats.Distribution[r.Rating]++
+			if r.Rating > 0 {
+				stats.Rated++
+				sum += r.Rating
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stats.Rated > 0 {
+		stats.Average = float64(sum) / float64(stats.Rated)
+	}
+	return stats, nil
+}
@@ -0,0 +1,435 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KyleBanks/goodreads/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_FindDuplicateEditions(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&v=2", testAPIKey),
+			response: `<response><reviews>
+				<review><id>r1</id><book><id>10</id></book></review>
+				<review><id>r2</id><book><id>20</id></book></review>
+				<review><id>r3</id><book><id>11</id></book></review>
+			</reviews></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/id_to_work_id/10,20,11?key=%s", testAPIKey),
+			response:  `<response><work-ids><item>100</item><item>200</item><item>100</item></work-ids></response>`,
+		},
+	)
+	defer done()
+
+	d, err := c.FindDuplicateEditions(context.Background(), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]responses.Review{
+		"100": {
+			{ID: "r1", Book: responses.AuthorBook{ID: "10"}},
+			{ID: "r3", Book: responses.AuthorBook{ID: "11"}},
+		},
+	}, d)
+}
+
+func TestClient_TopAuthors(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&shelf=read&v=2", testAPIKey),
+		response: `<response><reviews>
+			<review><book><id>1</id><authors><author><id>10</id><name>B</name></author></authors></book></review>
+			<review><book><id>2</id><authors><author><id>20</id><name>A</name></author><author><id>10</id><name>B</name></author></authors></book></review>
+			<review><book><id>3</id><authors><author><id>20</id><name>A</name></author></authors></book></review>
+			<review><book><id>4</id><authors><author><id>30</id><name>C</name></author></authors></book></review>
+			<review><book><id>5</id><authors><author><id>10</id><name>B</name></author></authors></book></review>
+		</reviews></response>`,
+	})
+	defer done()
+
+	top, err := c.TopAuthors(context.Background(), "1", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []AuthorCount{
+		{Author: responses.Author{ID: "10", Name: "B"}, Count: 3},
+		{Author: responses.Author{ID: "20", Name: "A"}, Count: 2},
+	}, top)
+}
+
+func TestClient_RecentlyShelved(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&order=d&page=1&per_page=200&sort=date_added&v=2", testAPIKey),
+		response: `<response><reviews>
+			<review><id>3</id></review>
+			<review><id>2</id></review>
+			<review><id>1</id></review>
+		</reviews></response>`,
+	})
+	defer done()
+
+	r, err := c.RecentlyShelved(context.Background(), "1", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []responses.Review{{ID: "3"}, {ID: "2"}}, r)
+
+	r, err = c.RecentlyShelved(context.Background(), "1", 10)
+	assert.Nil(t, err)
+	assert.Len(t, r, 3)
+}
+
+func TestClient_ShelfEvents(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&order=d&page=1&per_page=200&sort=date_added&v=2", testAPIKey),
+		response: `<response><reviews>
+			<review><id>3</id><date_added>Mon Jun 03 00:00:00 -0700 2019</date_added></review>
+			<review><id>bad</id><date_added>yesterday</date_added></review>
+			<review><id>2</id><date_added>Sun Jun 02 00:00:00 -0700 2019</date_added></review>
+			<review><id>1</id><date_added>Sat Jun 01 00:00:00 -0700 2019</date_added></review>
+		</reviews></response>`,
+	})
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	since := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	var ids []string
+	err := c.ShelfEvents(ctx, "1", since, 5*time.Millisecond, func(r responses.Review) error {
+		ids = append(ids, r.ID)
+		return nil
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, []string{"2", "3"}, ids)
+}
+
+func TestClient_ShelfEvents_callbackError(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&order=d&page=1&per_page=200&sort=date_added&v=2", testAPIKey),
+		response:  `<response><reviews><review><id>1</id><date_added>Sat Jun 01 00:00:00 -0700 2019</date_added></review></reviews></response>`,
+	})
+	defer done()
+
+	stop := errors.New("stop")
+	err := c.ShelfEvents(context.Background(), "1", time.Time{}, time.Hour, func(responses.Review) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+}
+
+func TestClient_SmartShelf(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&order=d&page=1&per_page=200&shelf=read&sort=date_read&v=2", testAPIKey),
+			response: `<response><reviews>
+				<review><id>6</id><rating>5</rating><read_at>Sat Jun 01 00:00:00 +0000 2023</read_at><book><authors><author><id>30</id></author></authors></book></review>
+				<review><id>1</id><rating>5</rating><read_at>Sat Jun 01 00:00:00 +0000 2023</read_at><book><authors><author><id>10</id></author></authors></book></review>
+				<review><id>2</id><rating>3</rating><read_at>Sat Jun 01 00:00:00 +0000 2023</read_at><book><authors><author><id>10</id></author></authors></book></review>
+				<review><id>5</id><rating>4</rating><read_at>Sun Jan 01 00:00:00 +0000 2023</read_at><book><authors><author><id>20</id></author><author><id>10</id></author></authors></book></review>
+				<review><id>3</id><rating>5</rating><read_at>Sat Jun 01 00:00:00 +0000 2019</read_at><book><authors><author><id>10</id></author></authors></book></review>
+				<review><id>4</id><rating>5</rating><book><authors><author><id>10</id></author></authors></book></review>
+			</reviews></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&order=d&page=1&per_page=200&shelf=sci-fi&sort=date_read&v=2", testAPIKey),
+			response: `<response><reviews>
+				<review><id>7</id><rating>4</rating><read_at>Fri Dec 01 00:00:00 +0000 2023</read_at><book><authors><author><id>10</id></author></authors></book></review>
+				<review><id>1</id><rating>5</rating><read_at>Sat Jun 01 00:00:00 +0000 2023</read_at><book><authors><author><id>10</id></author></authors></book></review>
+			</reviews></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&shelf=read&v=2", testAPIKey),
+			response: `<response><reviews>
+				<review><id>1</id><rating>5</rating></review>
+				<review><id>2</id><rating>3</rating></review>
+			</reviews></response>`,
+		},
+	)
+	defer done()
+
+	r, err := c.SmartShelf(context.Background(), "1", ReviewFilter{
+		Shelves:    []string{"read", "sci-fi"},
+		MinRating:  4,
+		ReadAfter:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		ReadBefore: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		AuthorIDs:  []string{"10"},
+	})
+	assert.Nil(t, err)
+	var ids []string
+	for _, rv := range r {
+		ids = append(ids, rv.ID)
+	}
+	assert.Equal(t, []string{"1", "5", "7"}, ids)
+
+	r, err = c.SmartShelf(context.Background(), "1", ReviewFilter{Shelves: []string{"read"}, MaxRating: 3})
+	assert.Nil(t, err)
+	if assert.Len(t, r, 1) {
+		assert.Equal(t, "2", r[0].ID)
+	}
+}
+
+func TestClient_SmartShelf_stopsPaging(t *testing.T) {
+	// newestFirst holds 199 reviews read in 2023 followed by one read in
+	// 2022, and oldestFirst the same reviews in reverse.
+	newestFirst := make([]string, reviewsPerPage)
+	oldestFirst := make([]string, reviewsPerPage)
+	for i := range newestFirst {
+		year := 2023
+		if i == len(newestFirst)-1 {
+			year = 2022
+		}
+		r := fmt.Sprintf("<review><id>%d</id><read_at>Sat Jul 01 00:00:00 +0000 %d</read_at></review>", i, year)
+		newestFirst[i] = r
+		oldestFirst[len(oldestFirst)-1-i] = r
+	}
+	// Only the first page is served, so fetching the second fails the test.
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&order=d&page=1&per_page=200&shelf=read&sort=date_read&v=2", testAPIKey),
+		response:  fmt.Sprintf("<response><reviews>%s</reviews></response>", strings.Join(newestFirst, "")),
+	}, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&order=a&page=1&per_page=200&shelf=read&sort=date_read&v=2", testAPIKey),
+		response:  fmt.Sprintf("<response><reviews>%s</reviews></response>", strings.Join(oldestFirst, "")),
+	})
+	defer done()
+
+	r, err := c.SmartShelf(context.Background(), "1", ReviewFilter{
+		Shelves:   []string{"read"},
+		ReadAfter: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, r, reviewsPerPage-1)
+
+	r, err = c.SmartShelf(context.Background(), "1", ReviewFilter{
+		Shelves:    []string{"read"},
+		ReadBefore: time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Nil(t, err)
+	if assert.Len(t, r, 1) {
+		assert.Equal(t, fmt.Sprint(reviewsPerPage-1), r[0].ID)
+	}
+}
+
+func TestClient_ReadingTimeline(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&shelf=read&v=2", testAPIKey),
+		response: `<response><reviews>
+			<review><rating>4</rating><read_at>Mon Jun 03 00:00:00 +0000 2019</read_at><book><id>3</id></book></review>
+			<review><book><id>u1</id></book></review>
+			<review><rating>5</rating><read_at>Sat Jun 01 00:00:00 +0000 2019</read_at><book><id>1</id></book></review>
+			<review><read_at>someday</read_at><book><id>u2</id></book></review>
+		</reviews></response>`,
+	})
+	defer done()
+
+	tl, err := c.ReadingTimeline(context.Background(), "1")
+	assert.Nil(t, err)
+	var ids []string
+	for _, e := range tl {
+		ids = append(ids, e.Book.ID)
+	}
+	assert.Equal(t, []string{"1", "3", "u1", "u2"}, ids)
+	assert.Equal(t, 5, tl[0].Rating)
+	assert.True(t, tl[0].ReadAt.Equal(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, tl[2].ReadAt.IsZero())
+}
+
+func TestClient_ReadingTimeline_canceled(t *testing.T) {
+	var full []string
+	for i := 0; i < reviewsPerPage; i++ {
+		full = append(full, `<review><read_at>Sat Jun 01 00:00:00 +0000 2019</read_at></review>`)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			cancel()
+			return
+		}
+		_, _ = w.Write([]byte("<response><reviews>" + strings.Join(full, "") + "</reviews></response>"))
+	}))
+	defer s.Close()
+	c := &Client{APIKey: testAPIKey, httpClient: &httpClient{Client: http.DefaultClient, APIRoot: s.URL}}
+
+	tl, err := c.ReadingTimeline(ctx, "1")
+	assert.Equal(t, context.Canceled, err)
+	assert.Len(t, tl, reviewsPerPage)
+}
+
+func TestClient_UnratedBooks(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&shelf=read&v=2", testAPIKey),
+		response: `<response><reviews>
+			<review><rating>4</rating><book><id>1</id></book></review>
+			<review><rating>0</rating><book><id>2</id></book></review>
+			<review><rating>1</rating><book><id>3</id></book></review>
+			<review><rating>0</rating><book><id>4</id></book></review>
+			<review><book><id>5</id></book></review>
+		</reviews></response>`,
+	})
+	defer done()
+
+	books, err := c.UnratedBooks(context.Background(), "1", "read")
+	assert.Nil(t, err)
+	assert.Equal(t, []responses.AuthorBook{{ID: "2"}, {ID: "4"}}, books)
+}
+
+func TestClient_ReadCount(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=1&shelf=read&v=2", testAPIKey),
+		response:  `<response><reviews start="1" end="1" total="412"><review><id>1</id></review></reviews></response>`,
+	})
+	defer done()
+
+	n, err := c.ReadCount(context.Background(), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, 412, n)
+}
+
+func TestClient_ReadCount_private(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer s.Close()
+	c := &Client{APIKey: testAPIKey, httpClient: &httpClient{Client: http.DefaultClient, APIRoot: s.URL}}
+
+	n, err := c.ReadCount(context.Background(), "1")
+	assert.Equal(t, 0, n)
+	assert.Equal(t, ErrPrivateProfile, err)
+}
+
+func TestClient_LibraryOverlap(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&shelf=read&v=2", testAPIKey),
+			response: `<response><reviews>
+				<review><rating>5</rating><book><id>10</id></book></review>
+				<review><rating>2</rating><book><id>20</id></book></review>
+				<review><rating>4</rating><book><id>30</id></book></review>
+				<review><book><id>40</id></book></review>
+			</reviews></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/list/2.xml?key=%s&page=1&per_page=200&shelf=read&v=2", testAPIKey),
+			response: `<response><reviews>
+				<review><rating>4</rating><book><id>11</id></book></review>
+				<review><rating>5</rating><book><id>20</id></book></review>
+				<review><rating>3</rating><book><id>40</id></book></review>
+				<review><rating>3</rating><book><id>50</id></book></review>
+			</reviews></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/id_to_work_id/10,20,30,40?key=%s", testAPIKey),
+			response:  `<response><work-ids><item>100</item><item>200</item><item>300</item><item>400</item></work-ids></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/book/id_to_work_id/11,50?key=%s", testAPIKey),
+			response:  `<response><work-ids><item>100</item><item>500</item></work-ids></response>`,
+		},
+	)
+	defer done()
+
+	o, err := c.LibraryOverlap(context.Background(), "1", "2")
+	assert.Nil(t, err)
+	assert.Equal(t, []SharedBook{
+		{BookA: responses.AuthorBook{ID: "10"}, BookB: responses.AuthorBook{ID: "11"}, RatingA: 5, RatingB: 4},
+		{BookA: responses.AuthorBook{ID: "20"}, BookB: responses.AuthorBook{ID: "20"}, RatingA: 2, RatingB: 5},
+		{BookA: responses.AuthorBook{ID: "40"}, BookB: responses.AuthorBook{ID: "40"}, RatingA: 0, RatingB: 3},
+	}, o.Shared)
+	assert.Equal(t, 3.0/5.0, o.Similarity)
+	assert.Equal(t, 0.5, o.RatingAgreement)
+}
+
+func TestClient_LatestReviewPerBook(t *testing.T) {
+	c, done := newRoutedTestClient(t, decodeTestCase{
+		expectURL: fmt.Sprintf("/review/list/1.xml?key=%s&page=1&per_page=200&shelf=read&v=2", testAPIKey),
+		response: `<response><reviews>
+			<review><id>a1</id><date_added>Sat Jun 01 00:00:00 +0000 2019</date_added><book><id>1</id></book></review>
+			<review><id>a2</id><date_added>Sat Jun 01 00:00:00 +0000 2019</date_added><date_updated>Wed Jan 01 00:00:00 +0000 2020</date_updated><book><id>1</id></book></review>
+			<review><id>a3</id><date_added>Mon Jun 03 00:00:00 +0000 2019</date_added><book><id>1</id></book></review>
+			<review><id>b1</id><book><id>2</id></book></review>
+			<review><id>c1</id><date_added>Sat Jun 01 00:00:00 +0000 2019</date_added><book><id>3</id></book></review>
+			<review><id>c2</id><book><id>3</id></book></review>
+		</reviews></response>`,
+	})
+	defer done()
+
+	latest, err := c.LatestReviewPerBook(context.Background(), "1", "read")
+	assert.Nil(t, err)
+	ids := make(map[string]string)
+	for book, r := range latest {
+		ids[book] = r.ID
+	}
+	assert.Equal(t, map[string]string{"1": "a2", "2": "b1", "3": "c1"}, ids)
+}
+
+func TestClient_BookReadHistory(t *testing.T) {
+	c, done := newRoutedTestClient(t,
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/show_by_user_and_book.xml?book_id=50&key=%s&user_id=1", testAPIKey),
+			response: `<response><review><rating>4</rating><read_statuses>
+				<read_status><status>read</status><updated_at>Fri Mar 01 00:00:00 +0000 2019</updated_at></read_status>
+				<read_status><status>currently-reading</status><updated_at>Tue Jan 01 00:00:00 +0000 2019</updated_at></read_status>
+				<read_status><status>to-read</status><updated_at>Mon Dec 03 00:00:00 +0000 2018</updated_at></read_status>
+				<read_status><status>currently-reading</status><updated_at>Wed Jan 01 00:00:00 +0000 2020</updated_at></read_status>
+				<read_status><status>read</status><updated_at>Sat Feb 01 00:00:00 +0000 2020</updated_at></read_status>
+				<read_status><status>currently-reading</status><updated_at>Fri Jan 01 00:00:00 +0000 2021</updated_at></read_status>
+			</read_statuses></review></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/show_by_user_and_book.xml?book_id=51&key=%s&user_id=1", testAPIKey),
+			response:  `<response><review><rating>5</rating><read_at>Sat Jun 01 00:00:00 +0000 2019</read_at></review></response>`,
+		},
+		decodeTestCase{
+			expectURL: fmt.Sprintf("/review/show_by_user_and_book.xml?book_id=52&key=%s&user_id=1", testAPIKey),
+			response:  `<response><review></review></response>`,
+		},
+	)
+	defer done()
+
+	date := func(y int, m time.Month) time.Time { return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC) }
+	assertSessions := func(expect, got []ReadSession) {
+		if assert.Len(t, got, len(expect)) {
+			for i := range expect {
+				assert.True(t, expect[i].StartedAt.Equal(got[i].StartedAt), "session %d started", i)
+				assert.True(t, expect[i].ReadAt.Equal(got[i].ReadAt), "session %d read", i)
+				assert.Equal(t, expect[i].Rating, got[i].Rating)
+			}
+		}
+	}
+
+	sessions, err := c.BookReadHistory(context.Background(), "1", "50")
+	assert.Nil(t, err)
+	assertSessions([]ReadSession{
+		{StartedAt: date(2019, time.January), ReadAt: date(2019, time.March), Rating: 4},
+		{StartedAt: date(2020, time.January), ReadAt: date(2020, time.February), Rating: 4},
+		{StartedAt: date(2021, time.January), Rating: 4},
+	}, sessions)
+
+	sessions, err = c.BookReadHistory(context.Background(), "1", "51")
+	assert.Nil(t, err)
+	assertSessions([]ReadSession{{ReadAt: date(2019, time.June), Rating: 5}}, sessions)
+
+	sessions, err = c.BookReadHistory(context.Background(), "1", "52")
+	assert.Nil(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestClient_UserRatingStats(t *testing.T) {
+	c, done := newRoutedTestClient(t, reviewListPage("1", 1,
+		`<review><rating>5</rating></review>`,
+		`<review><rating>4</rating></review>`,
+		`<review><rating>0</rating></review>`,
+		`<review><rating>5</rating></review>`,
+		`<review><rating>1</rating></review>`,
+	))
+	defer done()
+
+	stats, err := c.UserRatingStats(context.Background(), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, &RatingStats{Rated: 4, Average: 3.75, Distribution: [6]int{1, 1, 0, 0, 1, 2}}, stats)
+}
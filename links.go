@@ -0,0 +1,110 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrUnrecognizedLink is returned by ResolveShortLink when a link does not
+// lead to a Goodreads book, author or user page.
+var ErrUnrecognizedLink = errors.New("not a Goodreads book, author or user link")
+
+// ResolveShortLink follows a shared Goodreads link, such as a gr.pn short
+// link, and returns the kind of page it leads to, one of "book", "author"
+// or "user", along with its ID. Links that are already full Goodreads URLs
+// resolve without a redirect.
+//
+// Only links on gr.pn or goodreads.com are requested, and redirects away
+// from them are not followed. The body of the page reached is not read.
+//
+// Dead links return ErrNotFound, and links that lead anywhere else return
+// an error wrapping ErrUnrecognizedLink.
+func (c *Client) ResolveShortLink(ctx context.Context, shortURL string) (kind, id string, err error) {
+	h, ok := c.httpClient.(*httpClient)
+	if !ok {
+		return "", "", errors.New("resolving links is not supported by this APIClient")
+	}
+
+	final, err := url.Parse(shortURL)
+	if err != nil {
+		return "", "", err
+	}
+	if !isGoodreadsURL(final, h.APIRoot) {
+		return "", "", fmt.Errorf("%s: %w", final, ErrUnrecognizedLink)
+	}
+
+	hc := http.Client{}
+	if h.Client != nil {
+		hc = *h.Client
+	}
+	hc.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		final = req.URL
+		if !isGoodreadsURL(req.URL, h.APIRoot) {
+			return http.ErrUseLastResponse
+		}
+		if len(via) >= maxLinkRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxLinkRedirects)
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, final.String(), nil)
+	if err != nil {
+		return "", "", err
+	}
+	if h.Verbose {
+		h.logger().Printf("%s %s", req.Method, req.URL)
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	res.Body.Close()
+
+	if !isGoodreadsURL(final, h.APIRoot) {
+		return "", "", fmt.Errorf("%s: %w", final, ErrUnrecognizedLink)
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return "", "", ErrNotFound
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return "", "", &StatusError{Code: res.StatusCode}
+	}
+
+	parts := strings.Split(strings.Trim(final.Path, "/"), "/")
+	if len(parts) != 3 || parts[1] != "show" {
+		return "", "", fmt.Errorf("%s: %w", final, ErrUnrecognizedLink)
+	}
+	switch parts[0] {
+	case "book", "author", "user":
+	default:
+		return "", "", fmt.Errorf("%s: %w", final, ErrUnrecognizedLink)
+	}
+	id, err = parseID(parts[2])
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", final, ErrUnrecognizedLink)
+	}
+	return parts[0], id, nil
+}
+
+// maxLinkRedirects bounds the redirects ResolveShortLink follows.
+const maxLinkRedirects = 10
+
+// isGoodreadsURL reports whether u is an http or https URL on gr.pn,
+// goodreads.com, one of its subdomains, or the host of the client's API
+// root.
+func isGoodreadsURL(u *url.URL, apiRoot string) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "gr.pn" || host == "goodreads.com" || strings.HasSuffix(host, ".goodreads.com") {
+		return true
+	}
+	root, err := url.Parse(apiRoot)
+	return err == nil && strings.EqualFold(root.Host, u.Host)
+}
@@ -0,0 +1,86 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ResolveShortLink(t *testing.T) {
+	var elsewhereRequests int32
+	elsewhere := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&elsewhereRequests, 1)
+	}))
+	defer elsewhere.Close()
+
+	mux := http.NewServeMux()
+	redirect := func(path, to string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, to, http.StatusMovedPermanently)
+		})
+	}
+	redirect("/s/book", "/book/show/50.Hatchet")
+	redirect("/s/author", "/author/show/18.Gary_Paulsen")
+	redirect("/s/user", "/user/show/38763538-kyle")
+	redirect("/s/list", "/list/show/1.Best_Books")
+	redirect("/s/elsewhere", elsewhere.URL+"/book/show/50")
+	mux.HandleFunc("/book/show/", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/author/show/", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/user/show/", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/list/show/", func(w http.ResponseWriter, r *http.Request) {})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c := &Client{httpClient: &httpClient{Client: http.DefaultClient, APIRoot: s.URL}}
+
+	testCases := []struct {
+		link     string
+		kind, id string
+		err      error
+	}{
+		{s.URL + "/s/book", "book", "50", nil},
+		{s.URL + "/s/author", "author", "18", nil},
+		{s.URL + "/s/user", "user", "38763538", nil},
+		{s.URL + "/book/show/50", "book", "50", nil},
+		{s.URL + "/s/list", "", "", ErrUnrecognizedLink},
+		{s.URL + "/s/elsewhere", "", "", ErrUnrecognizedLink},
+		{s.URL + "/s/dead", "", "", ErrNotFound},
+		{elsewhere.URL + "/book/show/50", "", "", ErrUnrecognizedLink},
+		{"file:///etc/passwd", "", "", ErrUnrecognizedLink},
+	}
+
+	for _, tc := range testCases {
+		kind, id, err := c.ResolveShortLink(context.Background(), tc.link)
+		assert.Equal(t, tc.kind, kind, tc.link)
+		assert.Equal(t, tc.id, id, tc.link)
+		assert.True(t, errors.Is(err, tc.err), tc.link)
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&elsewhereRequests))
+}
+
+func TestIsGoodreadsURL(t *testing.T) {
+	testCases := []struct {
+		in     string
+		expect bool
+	}{
+		{"https://www.goodreads.com/book/show/50", true},
+		{"https://Goodreads.com/book/show/50", true},
+		{"https://notgoodreads.com/book/show/50", false},
+		{"https://gr.pn/abc", true},
+		{"ftp://www.goodreads.com/book/show/50", false},
+		{"http://127.0.0.1:8080/book/show/50", true},
+		{"http://127.0.0.1:9090/book/show/50", false},
+	}
+
+	for _, tc := range testCases {
+		u, err := url.Parse(tc.in)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expect, isGoodreadsURL(u, "http://127.0.0.1:8080"), tc.in)
+	}
+}
@@ -0,0 +1,191 @@
+package goodreads
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoAPISecret is returned by the OAuth methods when the Client was not
+// configured with the secret of its API key, see WithAPISecret.
+var ErrNoAPISecret = errors.New("an API secret is required for OAuth")
+
+// ErrNoAccessToken is returned by methods that act on behalf of a user when
+// the Client was not configured with the user's access token, see
+// WithAccessToken.
+var ErrNoAccessToken = errors.New("an OAuth access token is required")
+
+// OAuthRequestToken starts the OAuth flow, returning a request token and
+// secret along with the URL to send the user to in order to authorize it.
+//
+// Goodreads takes the callback as part of the authorize URL rather than the
+// request token request, so it is added to the returned URL. Once the user
+// has authorized the token, exchange it with OAuthAccessToken.
+func (c *Client) OAuthRequestToken(ctx context.Context, callback string) (token, secret, authorizeURL string, err error) {
+	token, secret, err = c.oauthToken(ctx, "oauth/request_token", "", "")
+	if err != nil {
+		return "", "", "", err
+	}
+
+	h, _ := c.httpClient.(*httpClient)
+	q := url.Values{}
+	q.Set("oauth_token", token)
+	if callback != "" {
+		q.Set("oauth_callback", callback)
+	}
+	authorizeURL = fmt.Sprintf("%s/oauth/authorize?%s", h.APIRoot, q.Encode())
+	return token, secret, authorizeURL, nil
+}
+
+// OAuthAccessToken exchanges an authorized request token and secret, as
+// returned by OAuthRequestToken, for the user's access token and secret.
+func (c *Client) OAuthAccessToken(ctx context.Context, reqToken, reqSecret string) (token, secret string, err error) {
+	return c.oauthToken(ctx, "oauth/access_token", reqToken, reqSecret)
+}
+
+// AuthUserID returns the ID of the user whose access token the Client signs
+// its calls with.
+// https://www.goodreads.com/api/index#auth.user
+func (c *Client) AuthUserID(ctx context.Context) (string, error) {
+	if c.accessToken == "" {
+		return "", ErrNoAccessToken
+	}
+	var r struct {
+		User struct {
+			ID string `xml:"id,attr"`
+		} `xml:"user"`
+	}
+	err := c.get(ctx, "AuthUserID", "api/auth_user", xml.Unmarshal, c.defaultValues(), &r)
+	if err != nil {
+		return "", err
+	}
+	if r.User.ID == "" {
+		return "", ErrNotFound
+	}
+	return r.User.ID, nil
+}
+
+// signRequest signs an API call with the Client's consumer key and secret
+// and the access token given to WithAccessToken.
+func (c *Client) signRequest(req *http.Request) error {
+	if c.apiSecret == "" {
+		return ErrNoAPISecret
+	}
+	nonce, err := oauthNonce()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", oauthAuthorization(req, c.APIKey, c.apiSecret, c.accessToken, c.accessSecret, nonce, time.Now()))
+	return nil
+}
+
+// oauthToken requests a token from one of the OAuth token endpoints, signing
+// the request with the given token, if any.
+func (c *Client) oauthToken(ctx context.Context, endpoint, token, tokenSecret string) (string, string, error) {
+	if c.apiSecret == "" {
+		return "", "", ErrNoAPISecret
+	}
+	h, ok := c.httpClient.(*httpClient)
+	if !ok {
+		return "", "", errors.New("OAuth is not supported by this APIClient")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s", h.APIRoot, endpoint), nil)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := oauthNonce()
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Authorization", oauthAuthorization(req, c.APIKey, c.apiSecret, token, tokenSecret, nonce, time.Now()))
+
+	_, body, err := h.do(req)
+	if err != nil {
+		return "", "", err
+	}
+
+	v, err := url.ParseQuery(string(body))
+	if err != nil {
+		return "", "", err
+	}
+	if v.Get("oauth_token") == "" {
+		return "", "", errors.New("missing oauth_token in OAuth response")
+	}
+	return v.Get("oauth_token"), v.Get("oauth_token_secret"), nil
+}
+
+// oauthAuthorization returns the Authorization header that signs a request
+// with HMAC-SHA1, as described by RFC 5849.
+func oauthAuthorization(req *http.Request, consumerKey, consumerSecret, token, tokenSecret, nonce string, now time.Time) string {
+	oauth := map[string]string{
+		"oauth_consumer_key":     consumerKey,
+		"oauth_nonce":            nonce,
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(now.Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if token != "" {
+		oauth["oauth_token"] = token
+	}
+
+	var params []string
+	for k, vs := range req.URL.Query() {
+		for _, v := range vs {
+			params = append(params, oauthEscape(k)+"="+oauthEscape(v))
+		}
+	}
+	for k, v := range oauth {
+		params = append(params, oauthEscape(k)+"="+oauthEscape(v))
+	}
+	sort.Strings(params)
+
+	base := *req.URL
+	base.RawQuery, base.Fragment = "", ""
+	baseString := strings.Join([]string{
+		req.Method,
+		oauthEscape(base.String()),
+		oauthEscape(strings.Join(params, "&")),
+	}, "&")
+
+	mac := hmac.New(sha1.New, []byte(oauthEscape(consumerSecret)+"&"+oauthEscape(tokenSecret)))
+	mac.Write([]byte(baseString))
+	oauth["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	keys := make([]string, 0, len(oauth))
+	for k := range oauth {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	header := make([]string, len(keys))
+	for i, k := range keys {
+		header[i] = fmt.Sprintf(`%s="%s"`, k, oauthEscape(oauth[k]))
+	}
+	return "OAuth " + strings.Join(header, ", ")
+}
+
+// oauthEscape percent-encodes a string as required by RFC 5849, which
+// differs from form encoding in how spaces are escaped.
+func oauthEscape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+func oauthNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,110 @@
+package goodreads
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuthAuthorization(t *testing.T) {
+	// The example from RFC 5849, section 1.2.
+	req := httptest.NewRequest(http.MethodGet, "http://photos.example.net/photos?file=vacation.jpg&size=original", nil)
+	header := oauthAuthorization(req, "dpf43f3p2l4k3l03", "kd94hf93k423kf44", "nnch734d00sl2jdk", "pfkkdhi9sl3r4s00", "kllo9940pd9333jh", time.Unix(1191242096, 0))
+
+	assert.Equal(t, `OAuth oauth_consumer_key="dpf43f3p2l4k3l03", oauth_nonce="kllo9940pd9333jh", oauth_signature="tR3%2BTy81lMeYAr%2FFid0kMTYa%2FWM%3D", oauth_signature_method="HMAC-SHA1", oauth_timestamp="1191242096", oauth_token="nnch734d00sl2jdk", oauth_version="1.0"`, header)
+}
+
+func TestOAuthEscape(t *testing.T) {
+	assert.Equal(t, "a%20b%2Bc~-._%2A", oauthEscape("a b+c~-._*"))
+}
+
+func newOAuthTestClient(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	s := httptest.NewServer(handler)
+	c := NewClient("consumer-key", WithAPISecret("consumer-secret"))
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+	return c, s.Close
+}
+
+func TestClient_OAuthRequestToken(t *testing.T) {
+	c, done := newOAuthTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/oauth/request_token", r.URL.Path)
+		auth := r.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(auth, "OAuth "), auth)
+		assert.Contains(t, auth, `oauth_consumer_key="consumer-key"`)
+		assert.NotContains(t, auth, "oauth_token=")
+		_, _ = w.Write([]byte("oauth_token=req-token&oauth_token_secret=req-secret"))
+	})
+	defer done()
+
+	token, secret, authorizeURL, err := c.OAuthRequestToken(context.Background(), "https://example.com/cb")
+	assert.Nil(t, err)
+	assert.Equal(t, "req-token", token)
+	assert.Equal(t, "req-secret", secret)
+	assert.True(t, strings.HasSuffix(authorizeURL, "/oauth/authorize?oauth_callback=https%3A%2F%2Fexample.com%2Fcb&oauth_token=req-token"), authorizeURL)
+}
+
+func TestClient_OAuthAccessToken(t *testing.T) {
+	c, done := newOAuthTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oauth/access_token", r.URL.Path)
+		assert.Contains(t, r.Header.Get("Authorization"), `oauth_token="req-token"`)
+		_, _ = w.Write([]byte("oauth_token=access-token&oauth_token_secret=access-secret"))
+	})
+	defer done()
+
+	token, secret, err := c.OAuthAccessToken(context.Background(), "req-token", "req-secret")
+	assert.Nil(t, err)
+	assert.Equal(t, "access-token", token)
+	assert.Equal(t, "access-secret", secret)
+}
+
+func TestClient_OAuthWithoutSecret(t *testing.T) {
+	c := NewClient("consumer-key")
+	_, _, _, err := c.OAuthRequestToken(context.Background(), "")
+	assert.Equal(t, ErrNoAPISecret, err)
+	_, _, err = c.OAuthAccessToken(context.Background(), "t", "s")
+	assert.Equal(t, ErrNoAPISecret, err)
+}
+
+func TestWithAccessToken(t *testing.T) {
+	var auth string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/auth_user", r.URL.Path)
+		auth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`<GoodreadsResponse><user id="38763538"><name>Kyle</name></user></GoodreadsResponse>`))
+	}))
+	defer s.Close()
+
+	c := NewClient("consumer-key", WithAccessToken("access-token", "access-secret"), WithAPISecret("consumer-secret"))
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL, Sign: c.signRequest}
+
+	id, err := c.AuthUserID(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "38763538", id)
+	assert.True(t, strings.HasPrefix(auth, "OAuth "), auth)
+	assert.Contains(t, auth, `oauth_consumer_key="consumer-key"`)
+	assert.Contains(t, auth, `oauth_token="access-token"`)
+	assert.Contains(t, auth, "oauth_signature=")
+}
+
+func TestWithAccessToken_sharedDefault(t *testing.T) {
+	c := NewClient("consumer-key", WithAccessToken("access-token", "access-secret"))
+	assert.NotNil(t, c.httpClient.(*httpClient).Sign)
+	assert.Nil(t, defaultAPIClient.(*httpClient).Sign)
+}
+
+func TestClient_AuthUserIDWithoutAccessToken(t *testing.T) {
+	_, err := NewClient("consumer-key").AuthUserID(context.Background())
+	assert.Equal(t, ErrNoAccessToken, err)
+}
+
+func TestClient_SignRequestWithoutSecret(t *testing.T) {
+	c := NewClient("consumer-key", WithAccessToken("access-token", "access-secret"))
+	req, _ := http.NewRequest(http.MethodGet, "https://www.goodreads.com/api/auth_user", nil)
+	assert.Equal(t, ErrNoAPISecret, c.signRequest(req))
+}
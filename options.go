@@ -0,0 +1,185 @@
+package goodreads
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option customizes a Client created by NewClient.
+type Option func(*Client)
+
+// WithAPISecret sets the secret of the Client's API key, which is needed to
+// sign OAuth requests.
+func WithAPISecret(secret string) Option {
+	return func(c *Client) {
+		c.apiSecret = secret
+	}
+}
+
+// WithAccessToken signs every API call with a user's OAuth access token and
+// secret, as returned by OAuthAccessToken, so that the Client can make calls
+// that require an authenticated user. WithAPISecret must also be given.
+func WithAccessToken(token, secret string) Option {
+	return func(c *Client) {
+		c.accessToken, c.accessSecret = token, secret
+		if h := c.ownHTTPClient(); h != nil {
+			h.Sign = c.signRequest
+		}
+	}
+}
+
+// WithEndpointTimeouts bounds the requests made by individual Client methods,
+// keyed by method name such as "SearchBooks" or "AuthorBooks".
+//
+// Methods without an entry are bound only by the timeout of the underlying
+// http.Client. Methods built on top of others, such as ResolveBook, are
+// bound by the timeouts of the methods they call.
+func WithEndpointTimeouts(timeouts map[string]time.Duration) Option {
+	return func(c *Client) {
+		c.timeouts = make(map[string]time.Duration, len(timeouts))
+		for method, d := range timeouts {
+			c.timeouts[method] = d
+		}
+	}
+}
+
+// WithSeriesAPIOrder makes SeriesShow return works in the order the API
+// lists them, rather than sorted by position.
+func WithSeriesAPIOrder() Option {
+	return func(c *Client) {
+		c.seriesAPIOrder = true
+	}
+}
+
+// WithCleanURLs strips tracking query parameters, such as utm_source or
+// from_search, from the links and image URLs of every response.
+func WithCleanURLs() Option {
+	return func(c *Client) {
+		c.cleanURLs = true
+	}
+}
+
+// WithCountBatching buffers the ISBNs passed to BookReviewCounts for up to
+// window, then fetches the counts of every buffered ISBN in a single request
+// and returns to each caller the counts it asked for.
+//
+// This trades up to window of added latency for far fewer requests when many
+// goroutines ask for counts at once.
+func WithCountBatching(window time.Duration) Option {
+	return func(c *Client) {
+		c.countBatcher = &countBatcher{window: window, fetch: c.bookReviewCounts}
+	}
+}
+
+// WithCacheBackend stores the results the Client reuses, such as work IDs,
+// in cache rather than in memory, so that a persistent Cache can keep them
+// across restarts.
+func WithCacheBackend(cache Cache) Option {
+	return func(c *Client) {
+		c.cacheBackend = cache
+	}
+}
+
+// WithLogger sets the Logger used for request logging, which otherwise
+// writes to stdout.
+func WithLogger(l Logger) Option {
+	return func(c *Client) {
+		if h := c.ownHTTPClient(); h != nil {
+			h.Logger = l
+		}
+	}
+}
+
+// WithCurlLogging logs every outgoing request as a runnable curl command,
+// with the API key replaced by a placeholder and Authorization credentials
+// redacted.
+func WithCurlLogging() Option {
+	return func(c *Client) {
+		if h := c.ownHTTPClient(); h != nil {
+			h.Curl = true
+		}
+	}
+}
+
+// WithRetryDecider resends failed requests for as long as decide returns
+// true. It is called after each failed attempt, counting from 1, with the
+// error and, if the server responded, the response, whose body has already
+// been read. Requests are not retried by default.
+//
+// Only idempotent requests are retried, unless WithRetryWrites is also
+// given. To wait between attempts, decide may sleep before returning.
+func WithRetryDecider(decide func(res *http.Response, err error, attempt int) bool) Option {
+	return func(c *Client) {
+		if h := c.ownHTTPClient(); h != nil {
+			h.RetryDecider = decide
+		}
+	}
+}
+
+// WithRetryWrites lets the decider given to WithRetryDecider retry requests
+// that are not idempotent, such as the POSTs of the OAuth flow.
+func WithRetryWrites() Option {
+	return func(c *Client) {
+		if h := c.ownHTTPClient(); h != nil {
+			h.RetryWrites = true
+		}
+	}
+}
+
+// WithRecorder saves every response the Client receives to a cassette file
+// in dir, creating it if needed, so that WithReplayer can later serve them.
+// Cassettes are matched to requests by method and URL, with the API key
+// left out.
+func WithRecorder(dir string) Option {
+	return func(c *Client) {
+		c.wrapTransport(func(next http.RoundTripper) http.RoundTripper {
+			return recorder{dir: dir, next: next}
+		})
+	}
+}
+
+// WithReplayer serves every request from the cassettes WithRecorder saved in
+// dir, without using the network, for deterministic tests. Requests without
+// a cassette fail.
+func WithReplayer(dir string) Option {
+	return func(c *Client) {
+		c.wrapTransport(func(http.RoundTripper) http.RoundTripper {
+			return replayer{dir: dir}
+		})
+	}
+}
+
+// wrapTransport replaces the transport of the Client's http.Client, which is
+// copied so that other users of it are unaffected.
+func (c *Client) wrapTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	h := c.ownHTTPClient()
+	if h == nil {
+		return
+	}
+	hc := http.Client{}
+	if h.Client != nil {
+		hc = *h.Client
+	}
+	next := hc.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	hc.Transport = wrap(next)
+	h.Client = &hc
+}
+
+// ownHTTPClient returns the Client's httpClient for configuration, first
+// replacing the shared default with a copy so that changes affect only
+// this Client.
+func (c *Client) ownHTTPClient() *httpClient {
+	h, ok := c.httpClient.(*httpClient)
+	if !ok {
+		return nil
+	}
+	if c.httpClient == defaultAPIClient {
+		cp := *h
+		h = &cp
+		c.httpClient = h
+	}
+	return h
+}
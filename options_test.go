@@ -0,0 +1,137 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithEndpointTimeouts(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte(`<response><author><id>1</id></author><user><id>2</id></user></response>`))
+	}))
+	defer s.Close()
+
+	c := NewClient(testAPIKey, WithEndpointTimeouts(map[string]time.Duration{
+		"AuthorShow": time.Millisecond,
+	}))
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+
+	_, err := c.AuthorShow("1")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected deadline exceeded, got %v", err)
+
+	u, err := c.UserShow("2")
+	assert.Nil(t, err)
+	assert.Equal(t, "2", u.ID)
+}
+
+type testLogger []string
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	*l = append(*l, fmt.Sprintf(format, v...))
+}
+
+func TestWithCurlLogging(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<response><user><id>2</id></user></response>`))
+	}))
+	defer s.Close()
+
+	var l testLogger
+	c := NewClient(testAPIKey, WithLogger(&l), WithCurlLogging())
+	c.httpClient.(*httpClient).APIRoot = s.URL
+
+	_, err := c.UserShow("2")
+	assert.Nil(t, err)
+	assert.Equal(t, testLogger{fmt.Sprintf("curl -H 'Accept: application/xml' '%s/user/show/2.xml?key=YOUR_API_KEY'", s.URL)}, l)
+
+	assert.False(t, defaultAPIClient.(*httpClient).Curl)
+	assert.Nil(t, defaultAPIClient.(*httpClient).Logger)
+}
+
+func TestWithRetryDecider(t *testing.T) {
+	var calls int
+	var failAll bool
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if failAll || calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`<response><user><id>2</id></user></response>`))
+	}))
+	defer s.Close()
+
+	var statuses []int
+	c := NewClient(testAPIKey, WithRetryDecider(func(res *http.Response, err error, attempt int) bool {
+		statuses = append(statuses, res.StatusCode)
+		return attempt < 5
+	}))
+	c.httpClient.(*httpClient).APIRoot = s.URL
+
+	u, err := c.UserShow("2")
+	assert.Nil(t, err)
+	assert.Equal(t, "2", u.ID)
+	assert.Equal(t, []int{503, 503}, statuses)
+	assert.Nil(t, defaultAPIClient.(*httpClient).RetryDecider)
+
+	calls, failAll = 0, true
+	_, err = c.UserShow("2")
+	assert.EqualError(t, err, "unexpected response code: 503")
+	assert.Equal(t, 5, calls)
+}
+
+func TestWithRetryDecider_writes(t *testing.T) {
+	var calls int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	retry := func(*http.Response, error, int) bool { return calls < 3 }
+
+	c := NewClient(testAPIKey, WithAPISecret("secret"), WithRetryDecider(retry))
+	c.httpClient.(*httpClient).APIRoot = s.URL
+	_, _, _, err := c.OAuthRequestToken(context.Background(), "")
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	c = NewClient(testAPIKey, WithAPISecret("secret"), WithRetryDecider(retry), WithRetryWrites())
+	c.httpClient.(*httpClient).APIRoot = s.URL
+	_, _, _, err = c.OAuthRequestToken(context.Background(), "")
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWithCacheBackend(t *testing.T) {
+	var calls int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`<response><work-ids><item>200</item></work-ids></response>`))
+	}))
+	defer s.Close()
+
+	cache := &MemoryCache{}
+	cache.Set(workIDCacheKey("1"), []byte("100"), 0)
+
+	c := NewClient(testAPIKey, WithCacheBackend(cache))
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+
+	w, err := c.IDToWorkID([]string{"1", "2"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"100", "200"}, w)
+	assert.Equal(t, 1, calls)
+
+	v, ok := cache.Get(workIDCacheKey("2"))
+	assert.True(t, ok)
+	assert.Equal(t, "200", string(v))
+}
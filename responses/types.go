@@ -1,8 +1,23 @@
 package responses
 
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/KyleBanks/goodreads/responses/work"
+)
+
 type Author struct {
 	ID               string       `xml:"id"`
 	Name             string       `xml:"name"`
+	Role             string       `xml:"role"`
 	ImageURL         string       `xml:"image_url"`
 	SmallImageURL    string       `xml:"small_image_url"`
 	LargeImageURL    string       `xml:"large_image_url"`
@@ -18,11 +33,17 @@ type Author struct {
 	Hometown         string       `xml:"hometown"`
 	BornAt           string       `xml:"born_at"`
 	DiedAt           string       `xml:"died_at"`
-	GoodreadsAuthor  bool         `xml:"goodreads_author"`
+	GoodreadsAuthor  GRBool       `xml:"goodreads_author"`
 	UserID           string       `xml:"user>user_id"`
 	Books            []AuthorBook `xml:"books>book"`
 }
 
+// GoodreadsURL returns the link to the author on Goodreads, constructed
+// from the ID if the response did not include one.
+func (a Author) GoodreadsURL() string {
+	return goodreadsURL(a.Link, "author", a.ID)
+}
+
 type AuthorBook struct {
 	ID                 string   `xml:"id"`
 	ISBN               string   `xml:"isbn"`
@@ -48,16 +69,210 @@ type AuthorBook struct {
 	Authors            []Author `xml:"authors>author"`
 }
 
+// GoodreadsURL returns the link to the book on Goodreads, constructed from
+// the ID if the response did not include one.
+func (b AuthorBook) GoodreadsURL() string {
+	return goodreadsURL(b.Link, "book", b.ID)
+}
+
+type Book struct {
+	ID                 string         `xml:"id"`
+	Title              string         `xml:"title"`
+	ISBN               string         `xml:"isbn"`
+	ISBN13             string         `xml:"isbn13"`
+	ImageURL           string         `xml:"image_url"`
+	SmallImageURL      string         `xml:"small_image_url"`
+	URL                string         `xml:"url"`
+	Link               string         `xml:"link"`
+	NumPages           int            `xml:"num_pages"`
+	Format             string         `xml:"format"`
+	EditionInformation string         `xml:"edition_information"`
+	Publisher          string         `xml:"publisher"`
+	LanguageCode       string         `xml:"language_code"`
+	PublicationDay     int            `xml:"publication_day"`
+	PublicationYear    int            `xml:"publication_year"`
+	PublicationMonth   int            `xml:"publication_month"`
+	AverageRating      float32        `xml:"average_rating"`
+	RatingsCount       int            `xml:"ratings_count"`
+	TextReviewsCount   int            `xml:"text_reviews_count"`
+	Description        string         `xml:"description"`
+	Authors            []Author       `xml:"authors>author"`
+	PopularShelves     []PopularShelf `xml:"popular_shelves>shelf"`
+	SeriesWorks        []SeriesWork   `xml:"series_works>series_work"`
+	SimilarBooks       []Book         `xml:"similar_books>book"`
+	Work               work.Work      `xml:"work"`
+}
+
+// GoodreadsURL returns the canonical link to the book on Goodreads,
+// constructed from the ID if the response did not include one.
+func (b Book) GoodreadsURL() string {
+	if b.URL != "" {
+		return b.URL
+	}
+	return goodreadsURL(b.Link, "book", b.ID)
+}
+
+var (
+	lineBreakTags = regexp.MustCompile(`(?i)<br\s*/?>|</?p(\s[^>]*)?>`)
+	htmlTags      = regexp.MustCompile(`<[^>]*>`)
+	spaces        = regexp.MustCompile(`[ \t\r]+`)
+)
+
+// PlainDescription returns the description as plain text. HTML tags are
+// removed, line breaks are kept as newlines and entities are unescaped.
+func (b Book) PlainDescription() string {
+	d := lineBreakTags.ReplaceAllString(b.Description, "\n")
+	d = html.UnescapeString(htmlTags.ReplaceAllString(d, ""))
+
+	lines := strings.Split(d, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// DescriptionExcerpt returns the plain text description shortened to at most
+// maxRunes runes, including a trailing ellipsis. The cut is made at a word
+// boundary unless the first word alone is too long. Descriptions that fit
+// are returned unchanged.
+func (b Book) DescriptionExcerpt(maxRunes int) string {
+	d := b.PlainDescription()
+	if utf8.RuneCountInString(d) <= maxRunes {
+		return d
+	}
+	if maxRunes <= 0 {
+		return ""
+	}
+
+	runes := []rune(d)[:maxRunes-1]
+	if !unicode.IsSpace([]rune(d)[maxRunes-1]) {
+		for i := len(runes) - 1; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				runes = runes[:i]
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(runes), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+// Pagination describes where a page of results lies within the full list.
+// Start and End are the 1-based positions of the first and last results on
+// the page, out of Total results.
+type Pagination struct {
+	Start int `xml:"start,attr"`
+	End   int `xml:"end,attr"`
+	Total int `xml:"total,attr"`
+}
+
+// TotalPages returns the number of pages of perPage results needed to hold
+// every result.
+func (p Pagination) TotalPages(perPage int) int {
+	if perPage <= 0 {
+		return 0
+	}
+	return (p.Total + perPage - 1) / perPage
+}
+
+// PopularShelf is a shelf name used by the community for a book, along with
+// the number of members that have used it.
+type PopularShelf struct {
+	Name  string `xml:"name,attr"`
+	Count int    `xml:"count,attr"`
+}
+
 type Review struct {
-	ID          string     `xml:"id"`
-	Book        AuthorBook `xml:"book"`
-	Rating      int        `xml:"rating"`
-	StartedAt   string     `xml:"started_at"`
-	ReadAt      string     `xml:"read_at"`
-	DateAdded   string     `xml:"date_added"`
-	DateUpdated string     `xml:"date_updated"`
-	ReadCount   int        `xml:"read_count"`
-	Body        string     `xml:"body"`
+	ID            string       `xml:"id"`
+	Book          AuthorBook   `xml:"book"`
+	Rating        int          `xml:"rating"`
+	StartedAt     string       `xml:"started_at"`
+	ReadAt        string       `xml:"read_at"`
+	DateAdded     string       `xml:"date_added"`
+	DateUpdated   string       `xml:"date_updated"`
+	ReadCount     int          `xml:"read_count"`
+	Body          string       `xml:"body"`
+	SpoilerFlag   GRBool       `xml:"spoiler_flag"`
+	SpoilersState string       `xml:"spoilers_state"`
+	CommentsCount int          `xml:"comments_count"`
+	ReadStatuses  []ReadStatus `xml:"read_statuses>read_status"`
+
+	// HasRating reports whether the response included a rating element,
+	// telling a rating of 0 apart from a response that left it out.
+	HasRating bool `xml:"-"`
+}
+
+// UnmarshalXML decodes a review as its fields are tagged, also recording
+// whether it had a rating element.
+func (r *Review) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type review Review
+	var aux struct {
+		review
+		// The shallower field takes the element from review.Rating.
+		Rating *string `xml:"rating"`
+	}
+	if err := d.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+	*r = Review(aux.review)
+	if aux.Rating != nil {
+		r.HasRating = true
+		if v := strings.TrimSpace(*aux.Rating); v != "" {
+			rating, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid review rating %q: %v", v, err)
+			}
+			r.Rating = rating
+		}
+	}
+	return nil
+}
+
+// ReadStatus is a change of a review's status, such as to "currently-reading"
+// or "read", which together record each time the book was read.
+type ReadStatus struct {
+	ID        string `xml:"id"`
+	Status    string `xml:"status"`
+	UpdatedAt string `xml:"updated_at"`
+}
+
+// UpdatedAtTime parses UpdatedAt, in the same way as Review.DateAddedTime.
+func (s ReadStatus) UpdatedAtTime() (time.Time, bool) {
+	return parseReviewTime(s.UpdatedAt)
+}
+
+// reviewTimeLayout is the layout of the timestamps on a Review, such as
+// "Sat Jun 01 00:00:00 -0700 2019".
+const reviewTimeLayout = time.RubyDate
+
+// DateAddedTime parses DateAdded. The second return value is false when the
+// date is empty or malformed, in which case the zero time is returned.
+func (r Review) DateAddedTime() (time.Time, bool) {
+	return parseReviewTime(r.DateAdded)
+}
+
+// ReadAtTime parses ReadAt, in the same way as DateAddedTime.
+func (r Review) ReadAtTime() (time.Time, bool) {
+	return parseReviewTime(r.ReadAt)
+}
+
+// DateUpdatedTime parses DateUpdated, in the same way as DateAddedTime.
+func (r Review) DateUpdatedTime() (time.Time, bool) {
+	return parseReviewTime(r.DateUpdated)
+}
+
+// StartedAtTime parses StartedAt, in the same way as DateAddedTime.
+func (r Review) StartedAtTime() (time.Time, bool) {
+	return parseReviewTime(r.StartedAt)
+}
+
+func parseReviewTime(s string) (time.Time, bool) {
+	t, err := time.Parse(reviewTimeLayout, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
 }
 
 // ReviewCounts defines the review statistics from the book.review_counts
@@ -75,6 +290,37 @@ type ReviewCounts struct {
 	AverageRating        string `json:"average_rating"`
 }
 
+// AverageRatingFloat parses AverageRating. The second return value is false
+// when the rating is unknown, either because it was empty or malformed, in
+// which case the rating returned is 0.
+func (r ReviewCounts) AverageRatingFloat() (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(r.AverageRating), 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+type Series struct {
+	ID               string       `xml:"id"`
+	Title            string       `xml:"title"`
+	Description      string       `xml:"description"`
+	Note             string       `xml:"note"`
+	SeriesWorksCount int          `xml:"series_works_count"`
+	PrimaryWorkCount int          `xml:"primary_work_count"`
+	Numbered         GRBool       `xml:"numbered"`
+	SeriesWorks      []SeriesWork `xml:"series_works>series_work"`
+}
+
+// SeriesWork is the entry of a work within a series. Entries listed on a
+// book carry the Series, while entries listed on a series carry the Work.
+type SeriesWork struct {
+	ID           string    `xml:"id"`
+	UserPosition string    `xml:"user_position"`
+	Series       *Series   `xml:"series"`
+	Work         work.Work `xml:"work"`
+}
+
 type User struct {
 	ID            string      `xml:"id"`
 	Name          string      `xml:"name"`
@@ -93,10 +339,71 @@ type User struct {
 	UserShelves   []UserShelf `xml:"user_shelves>user_shelf"`
 }
 
+// GoodreadsURL returns the link to the user's profile on Goodreads,
+// constructed from the ID if the response did not include one.
+func (u User) GoodreadsURL() string {
+	return goodreadsURL(u.Link, "user", u.ID)
+}
+
 type UserShelf struct {
 	ID            string `xml:"id"`
 	Name          string `xml:"name"`
 	BookCount     string `xml:"book_count"`
-	ExclusiveFlag bool   `xml:"exclusive_flag"`
+	ExclusiveFlag GRBool `xml:"exclusive_flag"`
 	Description   string `xml:"description"`
+
+	// Owner is the user the shelf belongs to, when it is not implied by
+	// the response, as with FollowedShelves.
+	Owner *User `xml:"-"`
+}
+
+// GRBool is a boolean as encoded by Goodreads, which varies by endpoint
+// between "true"/"false", "1"/"0" and "Y"/"N". Any of these, in any case,
+// decode from XML elements, XML attributes and JSON. Empty values decode as
+// false.
+type GRBool bool
+
+func (b *GRBool) parse(s string) error {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "1", "y", "yes":
+		*b = true
+	case "false", "0", "n", "no", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %q", s)
+	}
+	return nil
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (b *GRBool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	return b.parse(s)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (b *GRBool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.parse(attr.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting JSON booleans and
+// numbers as well as strings.
+func (b *GRBool) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	} else if s == "null" {
+		return nil
+	}
+	return b.parse(s)
+}
+
+func goodreadsURL(link, kind, id string) string {
+	if link = strings.TrimSpace(link); link != "" {
+		return link
+	}
+	return fmt.Sprintf("https://www.goodreads.com/%s/show/%s", kind, id)
 }
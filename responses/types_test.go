@@ -0,0 +1,163 @@
+package responses
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewCounts_AverageRatingFloat(t *testing.T) {
+	testCases := []struct {
+		in     string
+		expect float64
+		known  bool
+	}{
+		{"3.82", 3.82, true},
+		{" 4 ", 4, true},
+		{"0.00", 0, true},
+		{"", 0, false},
+		{"n/a", 0, false},
+	}
+
+	for _, tc := range testCases {
+		f, ok := ReviewCounts{AverageRating: tc.in}.AverageRatingFloat()
+		assert.Equal(t, tc.expect, f, tc.in)
+		assert.Equal(t, tc.known, ok, tc.in)
+	}
+}
+
+func TestPagination_TotalPages(t *testing.T) {
+	assert.Equal(t, 0, Pagination{}.TotalPages(20))
+	assert.Equal(t, 1, Pagination{Total: 20}.TotalPages(20))
+	assert.Equal(t, 2, Pagination{Total: 21}.TotalPages(20))
+	assert.Equal(t, 0, Pagination{Total: 21}.TotalPages(0))
+}
+
+func TestReview_DateAddedTime(t *testing.T) {
+	d, ok := Review{DateAdded: "Sat Jun 01 00:00:00 -0700 2019"}.DateAddedTime()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2019, 6, 1, 7, 0, 0, 0, time.UTC), d.UTC())
+
+	d, ok = Review{DateAdded: "2019-06-01"}.DateAddedTime()
+	assert.False(t, ok)
+	assert.True(t, d.IsZero())
+}
+
+func TestReview_ReadAtTime(t *testing.T) {
+	d, ok := Review{ReadAt: "Tue Jan 01 12:00:00 +0000 2019"}.ReadAtTime()
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC), d.UTC())
+
+	_, ok = Review{}.ReadAtTime()
+	assert.False(t, ok)
+}
+
+func TestReview_HasRating(t *testing.T) {
+	testCases := []struct {
+		in        string
+		rating    int
+		hasRating bool
+	}{
+		{`<review><id>1</id><rating>4</rating></review>`, 4, true},
+		{`<review><id>1</id><rating>0</rating></review>`, 0, true},
+		{`<review><id>1</id><rating> 3 </rating></review>`, 3, true},
+		{`<review><id>1</id></review>`, 0, false},
+	}
+
+	for _, tc := range testCases {
+		var r Review
+		assert.Nil(t, xml.Unmarshal([]byte(tc.in), &r), tc.in)
+		assert.Equal(t, "1", r.ID, tc.in)
+		assert.Equal(t, tc.rating, r.Rating, tc.in)
+		assert.Equal(t, tc.hasRating, r.HasRating, tc.in)
+	}
+
+	var r Review
+	assert.NotNil(t, xml.Unmarshal([]byte(`<review><rating>five</rating></review>`), &r))
+}
+
+func TestGoodreadsURL(t *testing.T) {
+	assert.Equal(t, "https://www.goodreads.com/book/show/50.Hatchet", Book{ID: "50", URL: "https://www.goodreads.com/book/show/50.Hatchet", Link: "https://www.goodreads.com/book/show/50"}.GoodreadsURL())
+	assert.Equal(t, "https://www.goodreads.com/book/show/50", Book{ID: "50", Link: "https://www.goodreads.com/book/show/50"}.GoodreadsURL())
+	assert.Equal(t, "https://www.goodreads.com/book/show/50", Book{ID: "50"}.GoodreadsURL())
+	assert.Equal(t, "https://www.goodreads.com/book/show/50", AuthorBook{ID: "50"}.GoodreadsURL())
+	assert.Equal(t, "https://www.goodreads.com/author/show/18", Author{ID: "18"}.GoodreadsURL())
+	assert.Equal(t, "https://www.goodreads.com/user/show/38763538", User{ID: "38763538"}.GoodreadsURL())
+	assert.Equal(t, "https://www.goodreads.com/user/show/38763538-kyle", User{ID: "38763538", Link: "https://www.goodreads.com/user/show/38763538-kyle"}.GoodreadsURL())
+}
+
+func TestBook_PlainDescription(t *testing.T) {
+	b := Book{Description: `<b>Hatchet</b> is a story of survival.<br /><br />Brian   &amp; his <i>hatchet</i>.<p>The end</p>`}
+	assert.Equal(t, "Hatchet is a story of survival.\n\nBrian & his hatchet.\nThe end", b.PlainDescription())
+}
+
+func TestBook_DescriptionExcerpt(t *testing.T) {
+	b := Book{Description: "Thirteen-year-old Brian Robeson, <i>haunted</i> by his secret knowledge."}
+	testCases := []struct {
+		max    int
+		expect string
+	}{
+		{100, "Thirteen-year-old Brian Robeson, haunted by his secret knowledge."},
+		{66, "Thirteen-year-old Brian Robeson, haunted by his secret knowledge."},
+		{41, "Thirteen-year-old Brian Robeson, haunted…"},
+		{40, "Thirteen-year-old Brian Robeson…"},
+		{34, "Thirteen-year-old Brian Robeson…"},
+		{10, "Thirteen…"},
+		{1, "…"},
+		{0, ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expect, b.DescriptionExcerpt(tc.max), "max %d", tc.max)
+	}
+
+	b = Book{Description: "Ünïcödé wörds everywhere"}
+	assert.Equal(t, "Ünïcödé…", b.DescriptionExcerpt(12))
+}
+
+func TestGRBool(t *testing.T) {
+	testCases := []struct {
+		in     string
+		expect GRBool
+	}{
+		{"true", true},
+		{"TRUE", true},
+		{"1", true},
+		{"Y", true},
+		{"y", true},
+		{"false", false},
+		{"0", false},
+		{"N", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		var v struct {
+			Elem GRBool `xml:"elem"`
+			Attr GRBool `xml:"attr,attr"`
+		}
+		err := xml.Unmarshal([]byte(fmt.Sprintf(`<r attr="%s"><elem>%s</elem></r>`, tc.in, tc.in)), &v)
+		assert.Nil(t, err, tc.in)
+		assert.Equal(t, tc.expect, v.Elem, tc.in)
+		assert.Equal(t, tc.expect, v.Attr, tc.in)
+
+		var j GRBool
+		assert.Nil(t, json.Unmarshal([]byte(strconv.Quote(tc.in)), &j), tc.in)
+		assert.Equal(t, tc.expect, j, tc.in)
+	}
+
+	for _, in := range []string{"true", "false", "1", "0", "null"} {
+		var j GRBool
+		assert.Nil(t, json.Unmarshal([]byte(in), &j), in)
+		assert.Equal(t, GRBool(in == "true" || in == "1"), j, in)
+	}
+
+	var v struct {
+		Elem GRBool `xml:"elem"`
+	}
+	assert.EqualError(t, xml.Unmarshal([]byte(`<r><elem>maybe</elem></r>`), &v), `invalid boolean "maybe"`)
+}
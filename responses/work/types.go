@@ -10,6 +10,7 @@ type Work struct {
 	OriginalPublicationDay   int     `xml:"original_publication_day"`
 	AverageRating            float64 `xml:"average_rating"`
 	BestBook                 Book    `xml:"best_book"`
+	BestBookID               int     `xml:"best_book_id"`
 }
 
 type Book struct {
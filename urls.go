@@ -0,0 +1,76 @@
+package goodreads
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// trackingParams are the query parameters Goodreads adds to links to track
+// how they were reached, besides the utm_ family.
+var trackingParams = map[string]bool{
+	"from_search": true,
+	"from_srp":    true,
+	"qid":         true,
+	"rank":        true,
+	"ref":         true,
+}
+
+// cleanURL strips tracking parameters from a URL, returning it unchanged if
+// it has none or cannot be parsed.
+func cleanURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.RawQuery == "" {
+		return s
+	}
+
+	q := u.Query()
+	var stripped bool
+	for k := range q {
+		if trackingParams[k] || strings.HasPrefix(k, "utm_") {
+			q.Del(k)
+			stripped = true
+		}
+	}
+	if !stripped {
+		return s
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// cleanURLs strips tracking parameters from the URL fields reachable from v,
+// which are the string fields named like ImageURL, Link or URI.
+func cleanURLs(v interface{}) {
+	cleanURLValue(reflect.ValueOf(v))
+}
+
+func cleanURLValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			cleanURLValue(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			cleanURLValue(v.Index(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Field(i)
+			if !f.CanSet() {
+				continue
+			}
+			if f.Kind() == reflect.String && isURLField(t.Field(i).Name) {
+				f.SetString(cleanURL(f.String()))
+				continue
+			}
+			cleanURLValue(f)
+		}
+	}
+}
+
+func isURLField(name string) bool {
+	return strings.HasSuffix(name, "URL") || strings.HasSuffix(name, "Link") || name == "URI"
+}
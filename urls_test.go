@@ -0,0 +1,58 @@
+package goodreads
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/KyleBanks/goodreads/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanURL(t *testing.T) {
+	testCases := []struct {
+		in, expect string
+	}{
+		{"https://www.goodreads.com/book/show/50.Hatchet", "https://www.goodreads.com/book/show/50.Hatchet"},
+		{"https://www.goodreads.com/book/show/50.Hatchet?from_search=true&from_srp=true&qid=x&rank=1", "https://www.goodreads.com/book/show/50.Hatchet"},
+		{"https://www.goodreads.com/author/show/18?utm_medium=api&utm_source=author_link", "https://www.goodreads.com/author/show/18"},
+		{"https://www.goodreads.com/review/list/1?page=2&ref=nav", "https://www.goodreads.com/review/list/1?page=2"},
+		{"https://www.goodreads.com/review/list/1?shelf=read", "https://www.goodreads.com/review/list/1?shelf=read"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expect, cleanURL(tc.in), tc.in)
+	}
+}
+
+func TestWithCleanURLs(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<response><book>
+			<id>50</id>
+			<url>https://www.goodreads.com/book/show/50.Hatchet?utm_medium=api</url>
+			<image_url>https://images.gr-assets.com/50.jpg?utm_source=x</image_url>
+			<description>See https://example.com/?utm_source=x</description>
+			<authors><author><link>https://www.goodreads.com/author/show/18?utm_medium=api&amp;utm_source=book_link</link></author></authors>
+			<similar_books><book><link>https://www.goodreads.com/book/show/51?from_search=true</link></book></similar_books>
+		</book></response>`))
+	}))
+	defer s.Close()
+
+	c := NewClient(testAPIKey, WithCleanURLs())
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+
+	b, err := c.BookShow("50")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://www.goodreads.com/book/show/50.Hatchet", b.URL)
+	assert.Equal(t, "https://images.gr-assets.com/50.jpg", b.ImageURL)
+	assert.Equal(t, "See https://example.com/?utm_source=x", b.Description)
+	assert.Equal(t, []responses.Author{{Link: "https://www.goodreads.com/author/show/18"}}, b.Authors)
+	assert.Equal(t, "https://www.goodreads.com/book/show/51", b.SimilarBooks[0].Link)
+
+	c = NewClient(testAPIKey)
+	c.httpClient = &httpClient{Client: http.DefaultClient, APIRoot: s.URL}
+	b, err = c.BookShow("50")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://www.goodreads.com/book/show/50.Hatchet?utm_medium=api", b.URL)
+}
@@ -0,0 +1,61 @@
+package goodreads
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRecorderAndReplayer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassettes")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/show/2.xml":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<response><user><id>2</id><name>Recorded</name></user></response>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	c := NewClient(testAPIKey, WithRecorder(dir))
+	c.httpClient.(*httpClient).APIRoot = s.URL
+	u, err := c.UserShow("2")
+	assert.Nil(t, err)
+	assert.Equal(t, "Recorded", u.Name)
+	_, err = c.UserShow("3")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Nil(t, defaultAPIClient.(*httpClient).Client.Transport)
+	s.Close()
+
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	for _, f := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		assert.Nil(t, err)
+		assert.False(t, strings.Contains(string(b), testAPIKey))
+	}
+
+	// Replayed with the server gone, and with a different API key.
+	c = NewClient("another-key", WithReplayer(dir))
+	c.httpClient.(*httpClient).APIRoot = s.URL
+	u, err = c.UserShow("2")
+	assert.Nil(t, err)
+	assert.Equal(t, "Recorded", u.Name)
+	_, err = c.UserShow("3")
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = c.UserShow("4")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no recorded response for GET "+s.URL+"/user/show/4.xml")
+}